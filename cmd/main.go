@@ -1,20 +1,157 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/a11y"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/coop"
 	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
 	"github.com/leetcode-golang-classroom/mine-sweeper/internal/layout"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/skin"
 )
 
 func main() {
+	coopAddr := flag.String("coop", "", "join a cooperative game server at host:port")
+	playerName := flag.String("name", "player", "player name shown to other cooperative players")
+	level := flag.String("level", "", "difficulty: easy, medium or hard (defaults to the last level played)")
+	cellSize := flag.Int("cell-size", layout.DefaultCellSize, "cell size in pixels on screen")
+	rows := flag.Int("rows", 0, "custom board rows (requires -cols and -mines)")
+	cols := flag.Int("cols", 0, "custom board columns (requires -rows and -mines)")
+	mines := flag.Int("mines", 0, "custom board mine count (requires -rows and -cols)")
+	announce := flag.String("announce", "", `print screen reader announcements to stdout ("-") or append them to a file`)
+	skinPath := flag.String("skin", "", `sprite skin descriptor (JSON), or "default" for the built-in sprites`)
+	seed := flag.Int64("seed", 0, "seed for the first board, to replay a known layout")
+	themeName := flag.String("theme", "", "color theme: "+themeNames())
+	loadPath := flag.String("load", "", `board layout file, one row per line with "*" for mines and "." for safe cells`)
+	flag.Parse()
+	// 有指定的 flag 才覆蓋設定檔
+	flagSet := map[string]bool{}
+	flag.Visit(func(f *flag.Flag) { flagSet[f.Name] = true })
+
 	ebiten.SetWindowSize(layout.DefaultScreenWidth, layout.DefaultScreenHeight)
+	ebiten.SetWindowResizingMode(ebiten.WindowResizingModeEnabled)
 	ebiten.SetWindowTitle(fmt.Sprintf("%s Mine Sweeper Grid", layout.LevelMessage[layout.Easy]))
-	gameInstance := game.NewGame(layout.DefaultRows, layout.DefaultCols, layout.DefaultMineCounts)
-	gameLayout := layout.NewGameLayout(gameInstance)
+	var gameLayout *layout.GameLayout
+	if *coopAddr != "" {
+		client, err := coop.Dial(*coopAddr, *playerName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer client.Close()
+		ebiten.SetWindowTitle("Co-op Mine Sweeper Grid")
+		gameLayout = layout.NewRemoteGameLayout(client)
+	} else {
+		gameInstance := game.NewGame(layout.DefaultRows, layout.DefaultCols, layout.DefaultMineCounts)
+		gameLayout = layout.NewGameLayout(gameInstance)
+		restart := false
+		if *level != "" {
+			parsed, err := game.ParseLevel(*level)
+			if err != nil {
+				log.Fatal(err)
+			}
+			gameLayout.SetLevel(parsed)
+			restart = true
+		}
+		if *rows > 0 || *cols > 0 || *mines > 0 {
+			if *rows <= 0 || *cols <= 0 || *mines <= 0 || *mines >= *rows**cols {
+				log.Fatalf("invalid custom board %dx%d with %d mines", *rows, *cols, *mines)
+			}
+			gameLayout.SetCustomBoard(layout.LevelSetup{Rows: *rows, Cols: *cols, MineCounts: *mines})
+			restart = true
+		}
+		if *seed != 0 {
+			gameLayout.SetSeed(*seed)
+			restart = true
+		}
+		if *loadPath != "" {
+			gameLayout.SetNextGame(loadGame(*loadPath))
+			restart = true
+		}
+		if restart {
+			gameLayout.Restart()
+		}
+	}
+	if *themeName != "" {
+		theme := layout.ThemeByName(*themeName)
+		if theme == nil {
+			log.Fatalf("unknown theme %q, available themes: %s", *themeName, themeNames())
+		}
+		gameLayout.SetTheme(theme)
+	}
+	if flagSet["cell-size"] {
+		gameLayout.SetCellSize(*cellSize)
+	}
+	if announcer := newAnnouncer(*announce); announcer != nil {
+		gameLayout.SetAnnouncer(announcer)
+	}
+	if *skinPath != "" {
+		gameLayout.SetSkin(loadSkin(*skinPath))
+	}
 	if err := ebiten.RunGame(gameLayout); err != nil {
 		log.Fatal(err)
 	}
 }
+
+// themeNames - 內建主題的名稱
+func themeNames() string {
+	names := make([]string, 0, len(layout.Themes))
+	for _, theme := range layout.Themes {
+		names = append(names, strconv.Quote(theme.Name))
+	}
+	return strings.Join(names, ", ")
+}
+
+// loadGame - 讀取 -load 指定的盤面檔案
+func loadGame(path string) *game.Game {
+	file, err := os.Open(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+	instance, err := game.LoadGame(file)
+	if err != nil {
+		log.Fatalf("%s: %v", path, err)
+	}
+	return instance
+}
+
+// loadSkin - 讀取 -skin 指定的 skin，"default" 代表內建的精靈圖
+func loadSkin(path string) *skin.Skin {
+	if path == "default" {
+		s, err := skin.Default()
+		if err != nil {
+			log.Fatal(err)
+		}
+		return s
+	}
+	s, err := skin.Load(os.DirFS(filepath.Dir(path)), filepath.Base(path))
+	if err != nil {
+		log.Fatal(err)
+	}
+	return s
+}
+
+// newAnnouncer - 依照 -announce 建立文字輸出，網頁版則看網址是否帶有 a11y 參數
+func newAnnouncer(target string) a11y.Announcer {
+	switch target {
+	case "":
+		if a11y.Requested() {
+			return a11y.Default()
+		}
+		return nil
+	case "-":
+		return a11y.NewWriterAnnouncer(os.Stdout)
+	}
+	file, err := os.OpenFile(target, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		log.Fatal(err)
+	}
+	return a11y.NewWriterAnnouncer(file)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/bot"
+)
+
+func main() {
+	rows := flag.Int("rows", 9, "board rows")
+	cols := flag.Int("cols", 9, "board cols")
+	mines := flag.Int("mines", 10, "mine counts")
+	games := flag.Int("games", 100, "number of games to play")
+	seed := flag.Int64("seed", 1, "seed of the first game, following games use seed+1, seed+2, ...")
+	timeout := flag.Duration("timeout", 5*time.Second, "time limit of every move, the bot is stopped when it is exceeded, 0 means no limit")
+	verbose := flag.Bool("v", false, "print the result of every game")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "usage: %s [flags] -- bot-command [args...]\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	process, err := bot.StartProcess(flag.Arg(0), flag.Args()[1:]...)
+	if err != nil {
+		log.Fatal(err)
+	}
+	process.SetTimeout(*timeout)
+	config := bot.Config{Rows: *rows, Cols: *cols, Mines: *mines}
+	summary := bot.Run(process, config, *games, *seed, func(index int, result bot.Result) {
+		if result.Err != nil {
+			log.Printf("game %d (seed %d): %v", index, result.Seed, result.Err)
+		}
+		if *verbose {
+			fmt.Printf("game %d seed=%d won=%t moves=%d 3bv=%d time=%s\n",
+				index, result.Seed, result.Won, result.Moves, result.ThreeBV, result.Duration)
+		}
+	})
+	process.Close()
+
+	fmt.Printf("board      %dx%d, %d mines\n", config.Rows, config.Cols, config.Mines)
+	if summary.Games < *games {
+		fmt.Printf("stopped    bot unavailable after %d of %d games\n", summary.Games, *games)
+	}
+	fmt.Printf("games      %d (%d errors)\n", summary.Games, summary.Errors)
+	fmt.Printf("win rate   %.2f%% (%d/%d)\n", 100*summary.WinRate(), summary.Wins, summary.Games)
+	fmt.Printf("avg time   %s\n", summary.AverageTime())
+	fmt.Printf("3BV/s      %.2f\n", summary.ThreeBVPerSecond())
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"runtime"
+	"time"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/solver"
+)
+
+func main() {
+	levelName := flag.String("level", "all", "easy, medium, hard or all")
+	games := flag.Int("games", 1000, "number of games per level")
+	seed := flag.Int64("seed", 1, "seed of the first game, following games use seed+1, seed+2, ...")
+	workers := flag.Int("workers", runtime.NumCPU(), "number of games played in parallel")
+	flag.Parse()
+
+	levels := []game.Level{game.Easy, game.Medium, game.Hard}
+	if *levelName != "all" {
+		level, err := game.ParseLevel(*levelName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		levels = []game.Level{level}
+	}
+
+	fmt.Printf("%-8s %8s %8s %10s %10s %12s %12s\n", "level", "games", "wins", "win rate", "no-guess", "guess/game", "time/game")
+	for _, level := range levels {
+		setup := game.LevelSetupMap[level]
+		startTime := time.Now()
+		result := solver.Benchmark(setup, *games, *seed, *workers)
+		fmt.Printf("%-8s %8d %8d %9.2f%% %10d %12.2f %12s\n",
+			game.LevelMessage[level], result.Games, result.Wins, 100*result.WinRate(),
+			result.NoGuessWins, result.AverageGuesses(), result.AverageTime().Round(time.Microsecond))
+		log.Printf("%s finished in %s", game.LevelMessage[level], time.Since(startTime).Round(time.Millisecond))
+	}
+}
@@ -0,0 +1,39 @@
+// minesweeper-bot - 最簡單的 bot 範例，每一步翻開 solver 算出地雷機率最低的格子
+//
+//	go build -o /tmp/minesweeper-bot ./cmd/minesweeper-bot
+//	go run ./cmd/minesweeper-arena -games 100 -- /tmp/minesweeper-bot
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"log"
+	"os"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/bot"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/solver"
+)
+
+func main() {
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	encoder := json.NewEncoder(os.Stdout)
+	for scanner.Scan() {
+		var message bot.HostMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			log.Fatalf("invalid message: %v", err)
+		}
+		switch message.Type {
+		case bot.MessageBoard:
+			cell, _, _ := solver.Analyze(message.Board, message.Mines).Best()
+			if err := encoder.Encode(bot.Move{Action: bot.ActionReveal, Row: cell.Row, Col: cell.Col}); err != nil {
+				log.Fatal(err)
+			}
+		case bot.MessageQuit:
+			return
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+}
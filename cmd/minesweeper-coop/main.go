@@ -0,0 +1,27 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/coop"
+)
+
+func main() {
+	addr := flag.String("addr", ":7777", "listen address")
+	rows := flag.Int("rows", 16, "board rows")
+	cols := flag.Int("cols", 16, "board cols")
+	mines := flag.Int("mines", 40, "mine counts")
+	flag.Parse()
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("coop server listening on %s (%dx%d, %d mines)", listener.Addr(), *rows, *cols, *mines)
+	server := coop.NewServer(coop.NewSession(*rows, *cols, *mines))
+	if err := server.Serve(listener); err != nil {
+		log.Fatal(err)
+	}
+}
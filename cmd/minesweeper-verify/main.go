@@ -0,0 +1,61 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+)
+
+func main() {
+	commitment := flag.String("commitment", "", "commitment hash shown before the game started")
+	seed := flag.Int64("seed", 0, "seed revealed after the game")
+	salt := flag.String("salt", "", "salt revealed after the game")
+	levelName := flag.String("level", "easy", "easy, medium or hard")
+	rows := flag.Int("rows", 0, "rows of a custom board, overrides -level")
+	cols := flag.Int("cols", 0, "columns of a custom board, overrides -level")
+	mines := flag.Int("mines", 0, "mines of a custom board, overrides -level")
+	load := flag.String("load", "", "board layout file to verify instead of regenerating the board from -seed")
+	flag.Parse()
+
+	if *commitment == "" || *salt == "" {
+		log.Fatal("-commitment and -salt are required")
+	}
+	var layout []string
+	var ok bool
+	if *load != "" {
+		// 盤面不是由 seed 產生時，直接比對檔案中的地雷配置
+		file, err := os.Open(*load)
+		if err != nil {
+			log.Fatal(err)
+		}
+		loaded, err := game.LoadGame(file)
+		file.Close()
+		if err != nil {
+			log.Fatalf("%s: %v", *load, err)
+		}
+		layout = loaded.Board.Layout()
+		ok = game.Proof{Commitment: *commitment, Seed: *seed, Salt: *salt, Layout: layout}.Verify()
+	} else {
+		level, err := game.ParseLevel(*levelName)
+		if err != nil {
+			log.Fatal(err)
+		}
+		setup := game.LevelSetupMap[level]
+		if *rows > 0 || *cols > 0 || *mines > 0 {
+			setup = game.LevelSetup{Rows: *rows, Cols: *cols, MineCounts: *mines}
+		}
+		layout, ok = game.VerifySeed(*commitment, *seed, *salt, setup.Rows, setup.Cols, setup.MineCounts)
+	}
+
+	for _, line := range layout {
+		fmt.Println(line)
+	}
+	if !ok {
+		fmt.Println("MISMATCH: the board does not match the commitment")
+		os.Exit(1)
+	}
+	fmt.Println("OK: the board matches the commitment")
+}
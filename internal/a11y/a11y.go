@@ -0,0 +1,90 @@
+package a11y
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+)
+
+// Announcer - 把畫面上的變化以文字唸給螢幕閱讀器
+type Announcer interface {
+	Announce(message string)
+}
+
+// WriterAnnouncer - 每則訊息寫成一行，用於 stdout 或文字紀錄檔
+type WriterAnnouncer struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewWriterAnnouncer - 建立寫到 w 的 Announcer
+func NewWriterAnnouncer(w io.Writer) *WriterAnnouncer {
+	return &WriterAnnouncer{w: w}
+}
+
+// Announce - 寫出一行訊息
+func (a *WriterAnnouncer) Announce(message string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	fmt.Fprintln(a.w, message)
+}
+
+// position - 以 1 開始的列與欄描述格子位置
+func position(row, col int) string {
+	return fmt.Sprintf("row %d column %d", row+1, col+1)
+}
+
+// plural - 依照數量加上複數
+func plural(count int, noun string) string {
+	if count == 1 {
+		return fmt.Sprintf("1 %s", noun)
+	}
+	return fmt.Sprintf("%d %ss", count, noun)
+}
+
+// DescribeCell - 描述格子目前的狀態，例如 "row 3 column 5, 2 mines nearby"
+func DescribeCell(board *game.Board, row, col int) string {
+	cell := board.GetCell(row, col)
+	var state string
+	switch {
+	case cell.Flagged:
+		state = "flagged"
+	case !cell.Revealed:
+		state = "hidden"
+	case cell.IsMine:
+		state = "mine"
+	case cell.AdjacenetMines == 0:
+		state = "empty"
+	default:
+		state = plural(cell.AdjacenetMines, "mine") + " nearby"
+	}
+	return fmt.Sprintf("%s, %s", position(row, col), state)
+}
+
+// DescribeEvent - 描述遊戲事件的結果，沒有需要唸出的內容時回傳空字串
+func DescribeEvent(board *game.Board, event game.Event) string {
+	switch event.Type {
+	case game.EventReveal:
+		// 踩到地雷由 EventExplode 描述
+		if board.GetCell(event.Row, event.Col).IsMine {
+			return ""
+		}
+		if event.Cells == 1 {
+			return "revealed " + DescribeCell(board, event.Row, event.Col)
+		}
+		return fmt.Sprintf("revealed %s from %s", plural(event.Cells, "cell"), position(event.Row, event.Col))
+	case game.EventFlag:
+		return fmt.Sprintf("flagged %s, %s left", position(event.Row, event.Col), plural(board.GetRemainingFlags(), "flag"))
+	case game.EventUnflag:
+		return fmt.Sprintf("unflagged %s, %s left", position(event.Row, event.Col), plural(board.GetRemainingFlags(), "flag"))
+	case game.EventChord:
+		return "chord at " + position(event.Row, event.Col)
+	case game.EventExplode:
+		return fmt.Sprintf("mine at %s, game over", position(event.Row, event.Col))
+	case game.EventWin:
+		return "all safe cells revealed, you win"
+	}
+	return ""
+}
@@ -0,0 +1,99 @@
+package a11y
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDescribeCell(t *testing.T) {
+	board := game.NewBoardFromVisibleRows([]string{
+		"#F1",
+		"02*",
+	}, 9)
+	tests := []struct {
+		name     string
+		row, col int
+		want     string
+	}{
+		{name: "hidden", row: 0, col: 0, want: "row 1 column 1, hidden"},
+		{name: "flagged", row: 0, col: 1, want: "row 1 column 2, flagged"},
+		{name: "one mine", row: 0, col: 2, want: "row 1 column 3, 1 mine nearby"},
+		{name: "empty", row: 1, col: 0, want: "row 2 column 1, empty"},
+		{name: "two mines", row: 1, col: 1, want: "row 2 column 2, 2 mines nearby"},
+		{name: "mine", row: 1, col: 2, want: "row 2 column 3, mine"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DescribeCell(board, tt.row, tt.col))
+		})
+	}
+}
+
+func TestDescribeEvent(t *testing.T) {
+	board := game.NewBoardFromVisibleRows([]string{
+		"#F1",
+		"02*",
+	}, 9)
+	tests := []struct {
+		name  string
+		event game.Event
+		want  string
+	}{
+		{
+			name:  "single reveal describes the cell",
+			event: game.Event{Type: game.EventReveal, Row: 1, Col: 1, Cells: 1},
+			want:  "revealed row 2 column 2, 2 mines nearby",
+		},
+		{
+			name:  "flood fill reveal counts cells",
+			event: game.Event{Type: game.EventReveal, Row: 1, Col: 0, Cells: 4},
+			want:  "revealed 4 cells from row 2 column 1",
+		},
+		{
+			name:  "reveal of a mine is left to explode",
+			event: game.Event{Type: game.EventReveal, Row: 1, Col: 2, Cells: 1},
+			want:  "",
+		},
+		{
+			name:  "flag reports remaining flags",
+			event: game.Event{Type: game.EventFlag, Row: 0, Col: 1},
+			want:  "flagged row 1 column 2, 9 flags left",
+		},
+		{
+			name:  "unflag",
+			event: game.Event{Type: game.EventUnflag, Row: 0, Col: 0},
+			want:  "unflagged row 1 column 1, 9 flags left",
+		},
+		{
+			name:  "chord",
+			event: game.Event{Type: game.EventChord, Row: 1, Col: 1, Cells: 2},
+			want:  "chord at row 2 column 2",
+		},
+		{
+			name:  "explode",
+			event: game.Event{Type: game.EventExplode, Row: 1, Col: 2},
+			want:  "mine at row 2 column 3, game over",
+		},
+		{
+			name:  "win",
+			event: game.Event{Type: game.EventWin, Row: 0, Col: 2},
+			want:  "all safe cells revealed, you win",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DescribeEvent(board, tt.event))
+		})
+	}
+}
+
+func TestWriterAnnouncer(t *testing.T) {
+	var buf bytes.Buffer
+	announcer := NewWriterAnnouncer(&buf)
+	announcer.Announce("row 1 column 1, hidden")
+	announcer.Announce("you win")
+	assert.Equal(t, "row 1 column 1, hidden\nyou win\n", buf.String())
+}
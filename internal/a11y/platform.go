@@ -0,0 +1,15 @@
+//go:build !js
+
+package a11y
+
+import "os"
+
+// Default - 桌面版預設把訊息寫到 stdout，由終端機的螢幕閱讀器唸出
+func Default() Announcer {
+	return NewWriterAnnouncer(os.Stdout)
+}
+
+// Requested - 桌面版由 -announce 參數開啟，這裡一律回傳 false
+func Requested() bool {
+	return false
+}
@@ -0,0 +1,51 @@
+//go:build js
+
+package a11y
+
+import (
+	"strings"
+	"sync"
+	"syscall/js"
+)
+
+var (
+	liveRegionOnce sync.Once
+	liveRegion     *LiveRegion
+)
+
+// LiveRegion - 網頁上畫面外的 ARIA live region，螢幕閱讀器會唸出更新的文字
+type LiveRegion struct {
+	element js.Value
+}
+
+// NewLiveRegion - 在 body 加入 role="status" 且 aria-live="polite" 的元素
+func NewLiveRegion() *LiveRegion {
+	document := js.Global().Get("document")
+	element := document.Call("createElement", "div")
+	element.Call("setAttribute", "role", "status")
+	element.Call("setAttribute", "aria-live", "polite")
+	element.Call("setAttribute", "aria-atomic", "true")
+	// 視覺上隱藏但仍然會被螢幕閱讀器讀到
+	element.Set("style", "position:absolute;width:1px;height:1px;overflow:hidden;clip:rect(0 0 0 0);white-space:nowrap;")
+	document.Get("body").Call("appendChild", element)
+	return &LiveRegion{element: element}
+}
+
+// Announce - 更新 live region 的文字
+func (r *LiveRegion) Announce(message string) {
+	r.element.Set("textContent", message)
+}
+
+// Default - 網頁版使用同一個 live region
+func Default() Announcer {
+	liveRegionOnce.Do(func() {
+		liveRegion = NewLiveRegion()
+	})
+	return liveRegion
+}
+
+// Requested - 網址帶有 a11y 參數時 (例如 index.html?a11y) 預設開啟
+func Requested() bool {
+	search := js.Global().Get("location").Get("search").String()
+	return strings.Contains(search, "a11y")
+}
@@ -0,0 +1,164 @@
+package bot
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+)
+
+// Player - 可以根據盤面回覆動作的對手，Conn 即為一種 Player
+type Player interface {
+	Send(message HostMessage) error
+	NextMove(message HostMessage) (Move, error)
+}
+
+// Config - 對局設定
+type Config struct {
+	Rows  int
+	Cols  int
+	Mines int
+}
+
+// Result - 單局結果
+type Result struct {
+	Seed     int64
+	Won      bool
+	Moves    int
+	ThreeBV  int
+	Duration time.Duration
+	Err      error // bot 回覆無效、逾時或中斷時的原因，視為落敗
+}
+
+// Summary - 多局統計
+type Summary struct {
+	Games         int
+	Wins          int
+	Errors        int
+	TotalDuration time.Duration
+	WinDuration   time.Duration
+	WinThreeBV    int
+}
+
+// WinRate - 勝率
+func (s Summary) WinRate() float64 {
+	if s.Games == 0 {
+		return 0
+	}
+	return float64(s.Wins) / float64(s.Games)
+}
+
+// AverageTime - 每局平均時間
+func (s Summary) AverageTime() time.Duration {
+	if s.Games == 0 {
+		return 0
+	}
+	return s.TotalDuration / time.Duration(s.Games)
+}
+
+// ThreeBVPerSecond - 獲勝對局的 3BV/s
+func (s Summary) ThreeBVPerSecond() float64 {
+	if s.WinDuration <= 0 {
+		return 0
+	}
+	return float64(s.WinThreeBV) / s.WinDuration.Seconds()
+}
+
+// Add - 累計單局結果
+func (s *Summary) Add(result Result) {
+	s.Games++
+	s.TotalDuration += result.Duration
+	if result.Err != nil {
+		s.Errors++
+	}
+	if result.Won {
+		s.Wins++
+		s.WinDuration += result.Duration
+		s.WinThreeBV += result.ThreeBV
+	}
+}
+
+// PlayGame - 以 seed 建立盤面並讓 player 下到分出勝負
+func PlayGame(player Player, config Config, index int, seed int64) Result {
+	gameInstance := game.NewGameWithSeed(config.Rows, config.Cols, config.Mines, seed)
+	result := Result{Seed: seed, ThreeBV: gameInstance.Board.ThreeBV()}
+	header := HostMessage{Game: index, Rows: config.Rows, Cols: config.Cols, Mines: config.Mines}
+
+	startTime := time.Now()
+	start := header
+	start.Type = MessageStart
+	start.RemainingFlags = gameInstance.Board.GetRemainingFlags()
+	if result.Err = player.Send(start); result.Err != nil {
+		return result
+	}
+	// 避免 bot 無限插旗/取消插旗，超過上限視為落敗
+	maxMoves := 4 * config.Rows * config.Cols
+	for !gameInstance.IsGameOver && !gameInstance.IsPlayerWin {
+		if result.Moves >= maxMoves {
+			result.Err = fmt.Errorf("bot: exceeded %d moves", maxMoves)
+			break
+		}
+		state := header
+		state.Type = MessageBoard
+		state.RemainingFlags = gameInstance.Board.GetRemainingFlags()
+		state.Board = gameInstance.Board.VisibleRows()
+		move, err := player.NextMove(state)
+		if err != nil {
+			result.Err = err
+			break
+		}
+		result.Moves++
+		if move.Row < 0 || move.Row >= config.Rows || move.Col < 0 || move.Col >= config.Cols {
+			result.Err = fmt.Errorf("bot: move out of bounds %+v", move)
+			break
+		}
+		cell := gameInstance.Board.GetCell(move.Row, move.Col)
+		switch move.Action {
+		case ActionReveal:
+			gameInstance.RevealCell(move.Row, move.Col)
+		case ActionFlag:
+			if !cell.Flagged {
+				gameInstance.ToggleFlag(move.Row, move.Col)
+			}
+		case ActionUnflag:
+			if cell.Flagged {
+				gameInstance.ToggleFlag(move.Row, move.Col)
+			}
+		default:
+			result.Err = fmt.Errorf("bot: unknown action %q", move.Action)
+		}
+		if result.Err != nil {
+			break
+		}
+	}
+	result.Duration = time.Since(startTime)
+	result.Won = gameInstance.IsPlayerWin && result.Err == nil
+
+	finish := header
+	finish.Type = MessageResult
+	finish.Won = result.Won
+	finish.RemainingFlags = gameInstance.Board.GetRemainingFlags()
+	finish.Board = gameInstance.Board.VisibleRows()
+	if err := player.Send(finish); err != nil && result.Err == nil {
+		result.Err = err
+	}
+	return result
+}
+
+// Run - 依序以 seed, seed+1, ... 進行 games 局
+func Run(player Player, config Config, games int, seed int64, onResult func(int, Result)) Summary {
+	var summary Summary
+	for index := 0; index < games; index++ {
+		result := PlayGame(player, config, index, seed+int64(index))
+		summary.Add(result)
+		if onResult != nil {
+			onResult(index, result)
+		}
+		// 讀寫失敗、逾時或 bot 中斷後無法繼續對局
+		if errors.Is(result.Err, ErrBotUnavailable) {
+			break
+		}
+	}
+	return summary
+}
@@ -0,0 +1,150 @@
+package bot
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// cheatingPlayer - 事先知道地雷位置，依序翻開所有安全格
+type cheatingPlayer struct {
+	board    *game.Board
+	messages []HostMessage
+}
+
+func (p *cheatingPlayer) Send(message HostMessage) error {
+	p.messages = append(p.messages, message)
+	return nil
+}
+
+func (p *cheatingPlayer) NextMove(message HostMessage) (Move, error) {
+	p.messages = append(p.messages, message)
+	for row, line := range message.Board {
+		for col := range line {
+			if line[col] == game.ViewHidden && !p.board.GetCell(row, col).IsMine {
+				return Move{Action: ActionReveal, Row: row, Col: col}, nil
+			}
+		}
+	}
+	return Move{Action: ActionFlag}, nil
+}
+
+func TestPlayGameWin(t *testing.T) {
+	config := Config{Rows: 5, Cols: 5, Mines: 3}
+	player := &cheatingPlayer{board: game.NewGameWithSeed(5, 5, 3, 7).Board}
+	result := PlayGame(player, config, 0, 7)
+	require.NoError(t, result.Err)
+	assert.True(t, result.Won)
+	assert.Equal(t, player.board.ThreeBV(), result.ThreeBV)
+	assert.Equal(t, MessageStart, player.messages[0].Type)
+	assert.Equal(t, MessageResult, player.messages[len(player.messages)-1].Type)
+	assert.True(t, player.messages[len(player.messages)-1].Won)
+}
+
+func TestPlayGameOverConn(t *testing.T) {
+	tests := []struct {
+		name    string
+		replies string
+		wantErr bool
+		fatal   bool
+	}{
+		{
+			name:    "invalid json forfeits",
+			replies: "not json\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown action forfeits",
+			replies: `{"action":"dance","row":0,"col":0}` + "\n",
+			wantErr: true,
+		},
+		{
+			name:    "closed output forfeits",
+			replies: "",
+			wantErr: true,
+			fatal:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sent bytes.Buffer
+			conn := NewConn(strings.NewReader(tt.replies), &sent)
+			result := PlayGame(conn, Config{Rows: 3, Cols: 3, Mines: 1}, 0, 1)
+			assert.Equal(t, tt.wantErr, result.Err != nil)
+			assert.Equal(t, tt.fatal, errors.Is(result.Err, ErrBotUnavailable))
+			assert.False(t, result.Won)
+			assert.Contains(t, sent.String(), `"type":"board"`)
+		})
+	}
+}
+
+func TestRunSummary(t *testing.T) {
+	reader, writer := io.Pipe()
+	defer reader.Close()
+	go func() {
+		// 永遠翻開左上角，盤面沒有地雷所以每局都會獲勝
+		for i := 0; i < 3; i++ {
+			writer.Write([]byte(`{"action":"reveal","row":0,"col":0}` + "\n"))
+		}
+		writer.Close()
+	}()
+	conn := NewConn(reader, io.Discard)
+	summary := Run(conn, Config{Rows: 3, Cols: 3, Mines: 0}, 3, 1, nil)
+	assert.Equal(t, 3, summary.Games)
+	assert.Equal(t, 3, summary.Wins)
+	assert.Equal(t, 1.0, summary.WinRate())
+	assert.Equal(t, 3, summary.WinThreeBV)
+}
+
+func TestMoveTimeout(t *testing.T) {
+	// bot 永遠不回覆
+	reader, writer := io.Pipe()
+	defer writer.Close()
+	conn := NewConn(reader, io.Discard)
+	conn.SetTimeout(10 * time.Millisecond)
+	result := PlayGame(conn, Config{Rows: 3, Cols: 3, Mines: 1}, 0, 1)
+	assert.ErrorIs(t, result.Err, ErrMoveTimeout)
+	assert.False(t, result.Won)
+}
+
+func TestRunStopsWhenBotUnavailable(t *testing.T) {
+	tests := []struct {
+		name      string
+		replies   string
+		wantGames int
+	}{
+		{
+			// 無效的回覆只輸掉該局，之後的對局照常進行
+			name:      "invalid move keeps playing",
+			replies:   "not json\n" + `{"action":"reveal","row":0,"col":0}` + "\n",
+			wantGames: 3,
+		},
+		{
+			name:      "closed output stops",
+			replies:   `{"action":"reveal","row":0,"col":0}` + "\n",
+			wantGames: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conn := NewConn(strings.NewReader(tt.replies), io.Discard)
+			summary := Run(conn, Config{Rows: 3, Cols: 3, Mines: 0}, 5, 1, nil)
+			assert.Equal(t, tt.wantGames, summary.Games)
+		})
+	}
+}
+
+func TestRemainingFlagsAlwaysSent(t *testing.T) {
+	var sent bytes.Buffer
+	conn := NewConn(strings.NewReader(""), &sent)
+	require.NoError(t, conn.Send(HostMessage{Type: MessageBoard, RemainingFlags: 0}))
+	// bot 必須能分辨「沒有旗子可插」與「沒有送出欄位」
+	assert.Contains(t, sent.String(), `"flags":0`)
+}
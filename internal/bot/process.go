@@ -0,0 +1,51 @@
+package bot
+
+import (
+	"os"
+	"os/exec"
+	"time"
+)
+
+// closeTimeout - 送出 quit 後等待 bot 結束的時間
+const closeTimeout = 2 * time.Second
+
+// Process - 以子行程執行的 bot，stdin/stdout 作為協定通道，stderr 直接轉給 host
+type Process struct {
+	*Conn
+	cmd *exec.Cmd
+}
+
+// StartProcess - 啟動 bot 子行程
+func StartProcess(name string, args ...string) (*Process, error) {
+	cmd := exec.Command(name, args...)
+	cmd.Stderr = os.Stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &Process{Conn: NewConn(stdout, stdin), cmd: cmd}, nil
+}
+
+// Close - 通知 bot 結束並等待子行程離開，超過 closeTimeout 仍未結束 (例如 bot 卡住) 時強制結束
+func (p *Process) Close() error {
+	p.Send(HostMessage{Type: MessageQuit})
+	if closer, ok := p.writer.(interface{ Close() error }); ok {
+		closer.Close()
+	}
+	done := make(chan error, 1)
+	go func() { done <- p.cmd.Wait() }()
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(closeTimeout):
+		p.cmd.Process.Kill()
+		return <-done
+	}
+}
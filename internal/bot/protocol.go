@@ -0,0 +1,126 @@
+package bot
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// 訊息類型 (host -> bot)
+const (
+	MessageStart  = "start"  // 新的一局開始
+	MessageBoard  = "board"  // 目前可見盤面，bot 需回覆一個 Move
+	MessageResult = "result" // 一局結束
+	MessageQuit   = "quit"   // 所有對局結束，bot 應該結束程式
+)
+
+// bot 可以回覆的動作
+const (
+	ActionReveal = "reveal"
+	ActionFlag   = "flag"
+	ActionUnflag = "unflag"
+)
+
+// HostMessage - host 送給 bot 的訊息，一行一個 JSON
+//
+// Board 使用 game.Board.VisibleRows 的編碼：'#' 未翻開、'F' 插旗、'0'~'8' 周圍地雷數、'*' 地雷
+type HostMessage struct {
+	Type           string   `json:"type"`
+	Game           int      `json:"game"`
+	Rows           int      `json:"rows"`
+	Cols           int      `json:"cols"`
+	Mines          int      `json:"mines"`
+	RemainingFlags int      `json:"flags"`
+	Board          []string `json:"board,omitempty"`
+	Won            bool     `json:"won,omitempty"`
+}
+
+// Move - bot 回覆的動作，一行一個 JSON
+type Move struct {
+	Action string `json:"action"`
+	Row    int    `json:"row"`
+	Col    int    `json:"col"`
+}
+
+// 與 bot 之間的連線錯誤都包含 ErrBotUnavailable，發生後無法繼續之後的對局
+var (
+	ErrBotUnavailable = errors.New("bot: unavailable")
+	ErrBotClosed      = fmt.Errorf("%w: closed output", ErrBotUnavailable)  // bot 在回覆前結束輸出
+	ErrMoveTimeout    = fmt.Errorf("%w: move timed out", ErrBotUnavailable) // bot 沒有在時限內回覆
+)
+
+// Conn - 以逐行 JSON 與 bot 溝通，讀取在背景進行以便對每一步設定時限
+type Conn struct {
+	writer  io.Writer
+	scanner *bufio.Scanner
+	lines   chan []byte   // bot 輸出的每一行，bot 結束輸出時關閉
+	readErr error         // lines 關閉後才能讀取
+	timeout time.Duration // 每一步的時限，0 代表不限制
+}
+
+// NewConn - r 為 bot 的 stdout，w 為 bot 的 stdin
+func NewConn(r io.Reader, w io.Writer) *Conn {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	conn := &Conn{writer: w, scanner: scanner, lines: make(chan []byte)}
+	go conn.readLines()
+	return conn
+}
+
+// SetTimeout - 設定每一步的時限，0 代表不限制
+func (c *Conn) SetTimeout(timeout time.Duration) {
+	c.timeout = timeout
+}
+
+// readLines - 在背景逐行讀取 bot 的輸出
+func (c *Conn) readLines() {
+	for c.scanner.Scan() {
+		c.lines <- append([]byte(nil), c.scanner.Bytes()...)
+	}
+	c.readErr = c.scanner.Err()
+	close(c.lines)
+}
+
+// Send - 送出不需要回覆的訊息
+func (c *Conn) Send(message HostMessage) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	if _, err := c.writer.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("%w: %w", ErrBotUnavailable, err)
+	}
+	return nil
+}
+
+// NextMove - 送出盤面並等待 bot 回覆一個動作，超過時限回傳 ErrMoveTimeout
+func (c *Conn) NextMove(message HostMessage) (Move, error) {
+	if err := c.Send(message); err != nil {
+		return Move{}, err
+	}
+	var deadline <-chan time.Time
+	if c.timeout > 0 {
+		timer := time.NewTimer(c.timeout)
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	select {
+	case line, ok := <-c.lines:
+		if !ok {
+			if c.readErr != nil {
+				return Move{}, fmt.Errorf("%w: %w", ErrBotUnavailable, c.readErr)
+			}
+			return Move{}, ErrBotClosed
+		}
+		var move Move
+		if err := json.Unmarshal(line, &move); err != nil {
+			return Move{}, fmt.Errorf("bot: invalid move %q: %w", line, err)
+		}
+		return move, nil
+	case <-deadline:
+		return Move{}, fmt.Errorf("%w after %s", ErrMoveTimeout, c.timeout)
+	}
+}
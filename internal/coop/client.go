@@ -0,0 +1,105 @@
+package coop
+
+import (
+	"encoding/json"
+	"net"
+	"sync"
+)
+
+// Client - 連線到合作模式 server，於背景接收最新快照
+type Client struct {
+	conn      net.Conn
+	mu        sync.Mutex
+	player    Player
+	snapshot  *Snapshot
+	lastError string
+	closed    bool
+}
+
+// Dial - 連線並以 name 加入遊戲
+func Dial(address, name string) (*Client, error) {
+	conn, err := net.Dial("tcp", address)
+	if err != nil {
+		return nil, err
+	}
+	client := &Client{conn: conn}
+	if err := writeMessage(conn, ClientMessage{Type: MessageJoin, Name: name}); err != nil {
+		conn.Close()
+		return nil, err
+	}
+	go client.readLoop()
+	return client, nil
+}
+
+// readLoop - 持續讀取 server 訊息並更新本地狀態
+func (c *Client) readLoop() {
+	scanner := newLineScanner(c.conn)
+	for scanner.Scan() {
+		var message ServerMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			continue
+		}
+		c.mu.Lock()
+		switch message.Type {
+		case MessageWelcome:
+			if message.Player != nil {
+				c.player = *message.Player
+			}
+		case MessageState:
+			if message.State != nil && (c.snapshot == nil || message.State.Version >= c.snapshot.Version) {
+				c.snapshot = message.State
+			}
+		case MessageError:
+			c.lastError = message.Error
+		}
+		c.mu.Unlock()
+	}
+	c.mu.Lock()
+	c.closed = true
+	c.mu.Unlock()
+}
+
+// Player - 取得 server 分配給自己的玩家資訊
+func (c *Client) Player() Player {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.player
+}
+
+// Snapshot - 取得目前最新的快照，尚未收到時回傳 nil
+func (c *Client) Snapshot() *Snapshot {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.snapshot
+}
+
+// LastError - 取得並清除最近一次被 server 拒絕的原因
+func (c *Client) LastError() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	lastError := c.lastError
+	c.lastError = ""
+	return lastError
+}
+
+// Closed - 連線是否已中斷
+func (c *Client) Closed() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.closed
+}
+
+// Move - 送出對盤面的動作
+func (c *Client) Move(action string, row, col int) error {
+	return writeMessage(c.conn, ClientMessage{Type: MessageMove, Action: action, Row: row, Col: col})
+}
+
+// MoveCursor - 送出游標位置
+func (c *Client) MoveCursor(row, col int) error {
+	return writeMessage(c.conn, ClientMessage{Type: MessageCursor, Row: row, Col: col})
+}
+
+// Close - 關閉連線
+func (c *Client) Close() error {
+	return c.conn.Close()
+}
@@ -0,0 +1,51 @@
+package coop
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+)
+
+// 訊息類型
+const (
+	MessageJoin    = "join"    // client -> server: 加入遊戲
+	MessageMove    = "move"    // client -> server: 對盤面的動作
+	MessageCursor  = "cursor"  // client -> server: 游標移動
+	MessageWelcome = "welcome" // server -> client: 分配的玩家資訊
+	MessageState   = "state"   // server -> client: 最新盤面快照
+	MessageError   = "error"   // server -> client: 動作被拒絕
+)
+
+// ClientMessage - client 送往 server 的訊息，一行一個 JSON
+type ClientMessage struct {
+	Type   string `json:"type"`
+	Name   string `json:"name,omitempty"`
+	Action string `json:"action,omitempty"`
+	Row    int    `json:"row"`
+	Col    int    `json:"col"`
+}
+
+// ServerMessage - server 送往 client 的訊息，一行一個 JSON
+type ServerMessage struct {
+	Type   string    `json:"type"`
+	Player *Player   `json:"player,omitempty"`
+	State  *Snapshot `json:"state,omitempty"`
+	Error  string    `json:"error,omitempty"`
+}
+
+// writeMessage - 以單行 JSON 寫出訊息
+func writeMessage(w io.Writer, message any) error {
+	data, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(data, '\n'))
+	return err
+}
+
+// newLineScanner - 建立可讀取較大快照的逐行 scanner
+func newLineScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	return scanner
+}
@@ -0,0 +1,166 @@
+package coop
+
+import (
+	"encoding/json"
+	"log"
+	"net"
+	"sync"
+	"time"
+)
+
+// writeTimeout - 連線結束時等待剩下的訊息送出的時間
+const writeTimeout = 5 * time.Second
+
+// Server - 透過 TCP 逐行 JSON 協定同步共享盤面
+type Server struct {
+	session *Session
+	mu      sync.Mutex
+	clients map[*serverClient]struct{}
+}
+
+// serverClient - 單一連線，慢速 client 不會阻塞廣播：盤面狀態只保留最新的一份，其他訊息以 channel 緩衝
+type serverClient struct {
+	conn     net.Conn
+	playerID int
+	outgoing chan ServerMessage // welcome 與錯誤訊息
+	state    chan ServerMessage // 最新的盤面快照，新的快照會取代還沒送出的舊快照
+	done     chan struct{}      // writeLoop 結束
+}
+
+// NewServer - 建立 server
+func NewServer(session *Session) *Server {
+	return &Server{
+		session: session,
+		clients: make(map[*serverClient]struct{}),
+	}
+}
+
+// Serve - 接受連線直到 listener 關閉
+func (s *Server) Serve(listener net.Listener) error {
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return err
+		}
+		go s.handleConn(conn)
+	}
+}
+
+// handleConn - 處理單一連線的生命週期
+func (s *Server) handleConn(conn net.Conn) {
+	client := &serverClient{
+		conn:     conn,
+		outgoing: make(chan ServerMessage, 16),
+		state:    make(chan ServerMessage, 1),
+		done:     make(chan struct{}),
+	}
+	defer conn.Close()
+	go client.writeLoop()
+
+	scanner := newLineScanner(conn)
+	for scanner.Scan() {
+		var message ClientMessage
+		if err := json.Unmarshal(scanner.Bytes(), &message); err != nil {
+			client.send(ServerMessage{Type: MessageError, Error: err.Error()})
+			continue
+		}
+		if !s.handleMessage(client, message) {
+			break
+		}
+	}
+	if client.playerID != 0 {
+		s.session.Leave(client.playerID)
+		s.mu.Lock()
+		delete(s.clients, client)
+		s.mu.Unlock()
+		s.broadcast()
+	}
+	// 等 writeLoop 把剩下的訊息 (例如 join first 的錯誤) 送完再關閉連線，client 不讀取時最多等 writeTimeout
+	close(client.outgoing)
+	conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	<-client.done
+}
+
+// handleMessage - 依照訊息類型更新 session，回傳 false 代表應該關閉連線
+func (s *Server) handleMessage(client *serverClient, message ClientMessage) bool {
+	if client.playerID == 0 {
+		if message.Type != MessageJoin {
+			client.send(ServerMessage{Type: MessageError, Error: "coop: join first"})
+			return false
+		}
+		player := s.session.Join(message.Name)
+		client.playerID = player.ID
+		s.mu.Lock()
+		s.clients[client] = struct{}{}
+		s.mu.Unlock()
+		client.send(ServerMessage{Type: MessageWelcome, Player: &player})
+		s.broadcast()
+		return true
+	}
+	var err error
+	switch message.Type {
+	case MessageMove:
+		err = s.session.Apply(client.playerID, message.Action, message.Row, message.Col)
+	case MessageCursor:
+		err = s.session.MoveCursor(client.playerID, message.Row, message.Col)
+	default:
+		err = ErrUnknownAction
+	}
+	if err != nil {
+		client.send(ServerMessage{Type: MessageError, Error: err.Error()})
+		return true
+	}
+	s.broadcast()
+	return true
+}
+
+// broadcast - 把最新快照送給所有已加入的玩家
+func (s *Server) broadcast() {
+	snapshot := s.session.Snapshot()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for client := range s.clients {
+		client.sendState(ServerMessage{Type: MessageState, State: &snapshot})
+	}
+}
+
+// send - 非阻塞送出 welcome 與錯誤訊息，緩衝已滿時丟棄這則訊息
+func (c *serverClient) send(message ServerMessage) {
+	select {
+	case c.outgoing <- message:
+	default:
+		log.Printf("coop: drop message for player %d", c.playerID)
+	}
+}
+
+// sendState - 以最新的快照取代還沒送出的舊快照，呼叫端持有 Server.mu 因此不會同時有兩個寫入者
+func (c *serverClient) sendState(message ServerMessage) {
+	select {
+	case <-c.state:
+	default:
+	}
+	c.state <- message
+}
+
+// writeLoop - 將訊息寫回連線，welcome 與錯誤優先於快照，outgoing 關閉或寫入失敗時結束
+func (c *serverClient) writeLoop() {
+	defer close(c.done)
+	for {
+		var message ServerMessage
+		var ok bool
+		select {
+		case message, ok = <-c.outgoing:
+		default:
+			select {
+			case message, ok = <-c.outgoing:
+			case message, ok = <-c.state:
+			}
+		}
+		if !ok {
+			return
+		}
+		if err := writeMessage(c.conn, message); err != nil {
+			return
+		}
+	}
+}
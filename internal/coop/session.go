@@ -0,0 +1,224 @@
+package coop
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+)
+
+// 合作模式下玩家可以送出的動作
+const (
+	ActionReveal  = "reveal"  // 翻開格子
+	ActionFlag    = "flag"    // 插旗
+	ActionUnflag  = "unflag"  // 取消插旗
+	ActionRestart = "restart" // 重新開始
+)
+
+// 衝突規則對應的錯誤
+var (
+	ErrUnknownPlayer = errors.New("coop: unknown player")
+	ErrUnknownAction = errors.New("coop: unknown action")
+	ErrOutOfBounds   = errors.New("coop: cell out of bounds")
+	ErrGameFinished  = errors.New("coop: game already finished")
+	ErrGameRunning   = errors.New("coop: game still in progress")
+	ErrCellRevealed  = errors.New("coop: cell already revealed")
+	ErrCellFlagged   = errors.New("coop: cell is flagged")
+	ErrNoFlagsLeft   = errors.New("coop: no flags left")
+)
+
+// Player - 參與共享盤面的玩家
+type Player struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	ColorIndex int    `json:"color"` // 由畫面決定實際顏色
+	CursorRow  int    `json:"cursorRow"`
+	CursorCol  int    `json:"cursorCol"`
+	Reveals    int    `json:"reveals"` // 成功翻開次數
+	Flags      int    `json:"flags"`   // 成功插旗次數
+}
+
+// Snapshot - 某一版本的共享盤面狀態
+type Snapshot struct {
+	Version        int      `json:"version"`
+	Rows           int      `json:"rows"`
+	Cols           int      `json:"cols"`
+	MineCounts     int      `json:"mines"`
+	RemainingFlags int      `json:"remainingFlags"`
+	ElapsedSeconds int      `json:"elapsed"`
+	IsGameOver     bool     `json:"gameOver"`
+	IsPlayerWin    bool     `json:"playerWin"`
+	Cells          []string `json:"cells"` // game.Board.VisibleRows 的編碼
+	Players        []Player `json:"players"`
+}
+
+// Game - 由快照還原出只供顯示用的遊戲物件
+func (s *Snapshot) Game() *game.Game {
+	return &game.Game{
+		Board:       game.NewBoardFromVisibleRows(s.Cells, s.RemainingFlags),
+		IsGameOver:  s.IsGameOver,
+		IsPlayerWin: s.IsPlayerWin,
+		MineCounts:  s.MineCounts,
+	}
+}
+
+// Session - 多位玩家共用同一個 Board 的權威狀態
+//
+// 所有動作都在 mutex 保護下依照抵達順序套用，衝突規則如下：
+//   - 插旗與取消插旗是冪等的意圖，兩人同時插旗同一格不會互相抵銷
+//   - 已插旗的格子不能被翻開，必須先取消插旗
+//   - 已翻開的格子不能插旗
+//   - 遊戲結束後只接受 restart
+type Session struct {
+	mu         sync.Mutex
+	rows       int
+	cols       int
+	mineCounts int
+	game       *game.Game
+	players    map[int]*Player
+	nextID     int
+	version    int
+}
+
+// NewSession - 建立共享盤面
+func NewSession(rows, cols, mineCounts int) *Session {
+	return &Session{
+		rows:       rows,
+		cols:       cols,
+		mineCounts: mineCounts,
+		game:       game.NewGame(rows, cols, mineCounts),
+		players:    make(map[int]*Player),
+	}
+}
+
+// Join - 加入新玩家並分配顏色
+func (s *Session) Join(name string) Player {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextID++
+	player := &Player{
+		ID:         s.nextID,
+		Name:       name,
+		ColorIndex: s.nextID - 1,
+		CursorRow:  -1,
+		CursorCol:  -1,
+	}
+	s.players[player.ID] = player
+	s.version++
+	return *player
+}
+
+// Leave - 移除玩家
+func (s *Session) Leave(playerID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.players[playerID]; ok {
+		delete(s.players, playerID)
+		s.version++
+	}
+}
+
+// MoveCursor - 更新玩家游標位置，游標不影響盤面因此不受衝突規則限制
+func (s *Session) MoveCursor(playerID, row, col int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	player, ok := s.players[playerID]
+	if !ok {
+		return ErrUnknownPlayer
+	}
+	if player.CursorRow == row && player.CursorCol == col {
+		return nil
+	}
+	player.CursorRow, player.CursorCol = row, col
+	s.version++
+	return nil
+}
+
+// Apply - 套用玩家動作，回傳違反衝突規則的錯誤
+func (s *Session) Apply(playerID int, action string, row, col int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	player, ok := s.players[playerID]
+	if !ok {
+		return ErrUnknownPlayer
+	}
+	// 只有分出勝負後才能重新開始，避免任何一位玩家中途清掉大家的進度
+	if action == ActionRestart {
+		if !s.game.IsGameOver && !s.game.IsPlayerWin {
+			return ErrGameRunning
+		}
+		s.game = game.NewGame(s.rows, s.cols, s.mineCounts)
+		s.version++
+		return nil
+	}
+	if s.game.IsGameOver || s.game.IsPlayerWin {
+		return ErrGameFinished
+	}
+	if row < 0 || row >= s.rows || col < 0 || col >= s.cols {
+		return ErrOutOfBounds
+	}
+	cell := s.game.Board.GetCell(row, col)
+	switch action {
+	case ActionReveal:
+		if cell.Revealed {
+			return ErrCellRevealed
+		}
+		if cell.Flagged {
+			return ErrCellFlagged
+		}
+		s.game.RevealCell(row, col)
+		player.Reveals++
+	case ActionFlag:
+		if cell.Revealed {
+			return ErrCellRevealed
+		}
+		if cell.Flagged {
+			return nil
+		}
+		if s.game.Board.GetRemainingFlags() == 0 {
+			return ErrNoFlagsLeft
+		}
+		s.game.ToggleFlag(row, col)
+		player.Flags++
+	case ActionUnflag:
+		if !cell.Flagged {
+			return nil
+		}
+		s.game.ToggleFlag(row, col)
+	default:
+		return ErrUnknownAction
+	}
+	s.version++
+	return nil
+}
+
+// Version - 目前狀態版本，每次狀態改變都會遞增
+func (s *Session) Version() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.version
+}
+
+// Snapshot - 複製目前狀態，呼叫端可以自由讀取而不需要持有鎖
+func (s *Session) Snapshot() Snapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	players := make([]Player, 0, len(s.players))
+	for id := 1; id <= s.nextID; id++ {
+		if player, ok := s.players[id]; ok {
+			players = append(players, *player)
+		}
+	}
+	return Snapshot{
+		Version:        s.version,
+		Rows:           s.rows,
+		Cols:           s.cols,
+		MineCounts:     s.mineCounts,
+		RemainingFlags: s.game.Board.GetRemainingFlags(),
+		ElapsedSeconds: s.game.GetElapsedTime(),
+		IsGameOver:     s.game.IsGameOver,
+		IsPlayerWin:    s.game.IsPlayerWin,
+		Cells:          s.game.Board.VisibleRows(),
+		Players:        players,
+	}
+}
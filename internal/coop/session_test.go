@@ -0,0 +1,148 @@
+package coop
+
+import (
+	"encoding/json"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// findCells - 依照真實盤面找出一個地雷格與一個非零的安全格
+func findCells(t *testing.T, session *Session) (mine, safe [2]int) {
+	t.Helper()
+	foundMine, foundSafe := false, false
+	for row := 0; row < session.rows; row++ {
+		for col := 0; col < session.cols; col++ {
+			cell := session.game.Board.GetCell(row, col)
+			if cell.IsMine && !foundMine {
+				mine, foundMine = [2]int{row, col}, true
+			}
+			if !cell.IsMine && cell.AdjacenetMines > 0 && !foundSafe {
+				safe, foundSafe = [2]int{row, col}, true
+			}
+		}
+	}
+	require.True(t, foundMine && foundSafe)
+	return mine, safe
+}
+
+func TestSessionConflictRules(t *testing.T) {
+	session := NewSession(5, 5, 5)
+	alice := session.Join("alice")
+	bob := session.Join("bob")
+	mine, safe := findCells(t, session)
+
+	// 兩人同時插旗同一格不會互相抵銷
+	assert.NoError(t, session.Apply(alice.ID, ActionFlag, mine[0], mine[1]))
+	assert.NoError(t, session.Apply(bob.ID, ActionFlag, mine[0], mine[1]))
+	assert.Equal(t, "F", string(session.Snapshot().Cells[mine[0]][mine[1]]))
+	assert.Equal(t, 4, session.Snapshot().RemainingFlags)
+
+	// 遊戲進行中不能重新開始
+	assert.ErrorIs(t, session.Apply(bob.ID, ActionRestart, 0, 0), ErrGameRunning)
+
+	// 已插旗的格子不能被翻開
+	assert.ErrorIs(t, session.Apply(bob.ID, ActionReveal, mine[0], mine[1]), ErrCellFlagged)
+	assert.False(t, session.Snapshot().IsGameOver)
+
+	// 已翻開的格子不能插旗
+	assert.NoError(t, session.Apply(alice.ID, ActionReveal, safe[0], safe[1]))
+	assert.ErrorIs(t, session.Apply(bob.ID, ActionFlag, safe[0], safe[1]), ErrCellRevealed)
+	assert.ErrorIs(t, session.Apply(bob.ID, ActionReveal, safe[0], safe[1]), ErrCellRevealed)
+
+	// 取消插旗後才能翻開，踩到地雷後只接受 restart
+	assert.NoError(t, session.Apply(bob.ID, ActionUnflag, mine[0], mine[1]))
+	assert.NoError(t, session.Apply(bob.ID, ActionReveal, mine[0], mine[1]))
+	assert.True(t, session.Snapshot().IsGameOver)
+	assert.ErrorIs(t, session.Apply(alice.ID, ActionFlag, 0, 0), ErrGameFinished)
+	assert.NoError(t, session.Apply(alice.ID, ActionRestart, 0, 0))
+	assert.False(t, session.Snapshot().IsGameOver)
+
+	assert.ErrorIs(t, session.Apply(99, ActionReveal, 0, 0), ErrUnknownPlayer)
+	assert.ErrorIs(t, session.Apply(alice.ID, ActionReveal, 5, 0), ErrOutOfBounds)
+	assert.ErrorIs(t, session.Apply(alice.ID, "dance", 0, 0), ErrUnknownAction)
+}
+
+func TestSessionConcurrentFlags(t *testing.T) {
+	session := NewSession(8, 8, 64)
+	players := make([]Player, 4)
+	for i := range players {
+		players[i] = session.Join("player")
+	}
+	var wg sync.WaitGroup
+	for _, player := range players {
+		wg.Add(1)
+		go func(player Player) {
+			defer wg.Done()
+			for row := 0; row < 8; row++ {
+				for col := 0; col < 8; col++ {
+					session.Apply(player.ID, ActionFlag, row, col)
+					session.MoveCursor(player.ID, row, col)
+				}
+			}
+		}(player)
+	}
+	wg.Wait()
+	snapshot := session.Snapshot()
+	assert.Equal(t, 0, snapshot.RemainingFlags)
+	for _, line := range snapshot.Cells {
+		assert.Equal(t, "FFFFFFFF", line)
+	}
+}
+
+func TestServerBroadcastsToClients(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go NewServer(NewSession(4, 4, 0)).Serve(listener)
+
+	alice, err := Dial(listener.Addr().String(), "alice")
+	require.NoError(t, err)
+	defer alice.Close()
+	bob, err := Dial(listener.Addr().String(), "bob")
+	require.NoError(t, err)
+	defer bob.Close()
+
+	require.Eventually(t, func() bool {
+		snapshot := alice.Snapshot()
+		return snapshot != nil && len(snapshot.Players) == 2 && bob.Player().ID != 0
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, bob.MoveCursor(2, 3))
+	require.NoError(t, bob.Move(ActionReveal, 0, 0))
+	// 兩個連線加入的順序不固定，以 ID 找出 bob
+	require.Eventually(t, func() bool {
+		snapshot := alice.Snapshot()
+		for _, player := range snapshot.Players {
+			if player.ID == bob.Player().ID {
+				return snapshot.IsPlayerWin && player.CursorRow == 2 && player.CursorCol == 3
+			}
+		}
+		return false
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, []string{"0000", "0000", "0000", "0000"}, alice.Snapshot().Cells)
+}
+
+func TestServerReportsJoinFirstBeforeClosing(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	go NewServer(NewSession(4, 4, 0)).Serve(listener)
+
+	conn, err := net.Dial("tcp", listener.Addr().String())
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, writeMessage(conn, ClientMessage{Type: MessageMove, Action: ActionReveal}))
+
+	scanner := newLineScanner(conn)
+	require.True(t, scanner.Scan())
+	var message ServerMessage
+	require.NoError(t, json.Unmarshal(scanner.Bytes(), &message))
+	assert.Equal(t, MessageError, message.Type)
+	assert.Equal(t, "coop: join first", message.Error)
+	assert.False(t, scanner.Scan(), "server closes the connection after the error")
+}
@@ -0,0 +1,101 @@
+package daily
+
+import (
+	"sort"
+	"time"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/storage"
+)
+
+// FileName - 每日挑戰紀錄檔名稱
+const FileName = "daily.json"
+
+// Attempt - 某一天某難度唯一計分的挑戰
+type Attempt struct {
+	Date         string     `json:"date"` // game.DailyDateFormat
+	Level        game.Level `json:"level"`
+	Seed         int64      `json:"seed"`
+	Finished     bool       `json:"finished"` // false 代表開始後中途放棄
+	Won          bool       `json:"won"`
+	Milliseconds int64      `json:"ms"`
+}
+
+// Duration - 完成時間
+func (a Attempt) Duration() time.Duration {
+	return time.Duration(a.Milliseconds) * time.Millisecond
+}
+
+// History - 本機的每日挑戰紀錄
+type History struct {
+	file     storage.File
+	Attempts []Attempt `json:"attempts"`
+}
+
+// Load - 從 store 讀取紀錄，資料不存在時回傳空的紀錄
+func Load(store storage.Store) (*History, error) {
+	history := &History{}
+	file, err := storage.Open(store, FileName, history)
+	history.file = file
+	return history, err
+}
+
+// Save - 寫回資料檔
+func (h *History) Save() error {
+	return h.file.Save(h)
+}
+
+// find - 取得該日期與難度的挑戰
+func (h *History) find(date time.Time, level game.Level) *Attempt {
+	key := date.Format(game.DailyDateFormat)
+	for i := range h.Attempts {
+		if h.Attempts[i].Date == key && h.Attempts[i].Level == level {
+			return &h.Attempts[i]
+		}
+	}
+	return nil
+}
+
+// HasAttempt - 該日期與難度是否已經用掉計分機會
+func (h *History) HasAttempt(date time.Time, level game.Level) bool {
+	return h.find(date, level) != nil
+}
+
+// Start - 第一次動作時登記挑戰，之後同一天同難度的對局都只算練習，回傳是否成功登記
+func (h *History) Start(date time.Time, level game.Level, seed int64) bool {
+	if h.HasAttempt(date, level) {
+		return false
+	}
+	h.Attempts = append(h.Attempts, Attempt{
+		Date:  date.Format(game.DailyDateFormat),
+		Level: level,
+		Seed:  seed,
+	})
+	return true
+}
+
+// Finish - 記錄已登記挑戰的結果，只有第一次結束會被採用
+func (h *History) Finish(date time.Time, level game.Level, won bool, duration time.Duration) {
+	attempt := h.find(date, level)
+	if attempt == nil || attempt.Finished {
+		return
+	}
+	attempt.Finished = true
+	attempt.Won = won
+	attempt.Milliseconds = duration.Milliseconds()
+}
+
+// Recent - 由新到舊取出最近 n 筆挑戰
+func (h *History) Recent(n int) []Attempt {
+	attempts := append([]Attempt(nil), h.Attempts...)
+	sort.SliceStable(attempts, func(i, j int) bool {
+		if attempts[i].Date != attempts[j].Date {
+			return attempts[i].Date > attempts[j].Date
+		}
+		return attempts[i].Level < attempts[j].Level
+	})
+	if len(attempts) > n {
+		attempts = attempts[:n]
+	}
+	return attempts
+}
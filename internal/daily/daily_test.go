@@ -0,0 +1,55 @@
+package daily
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOneScoredAttemptPerDay(t *testing.T) {
+	history := &History{}
+	today := time.Date(2026, 10, 19, 9, 0, 0, 0, time.Local)
+	later := today.Add(3 * time.Hour)
+
+	assert.False(t, history.HasAttempt(today, game.Easy))
+	assert.True(t, history.Start(today, game.Easy, 1))
+	// 同一天再開始只算練習
+	assert.False(t, history.Start(later, game.Easy, 1))
+	// 不同難度各自有一次機會
+	assert.True(t, history.Start(today, game.Hard, 2))
+
+	history.Finish(today, game.Easy, true, 12345*time.Millisecond)
+	history.Finish(later, game.Easy, false, time.Second)
+	attempt := history.find(today, game.Easy)
+	require.NotNil(t, attempt)
+	assert.True(t, attempt.Finished)
+	assert.True(t, attempt.Won)
+	assert.Equal(t, 12345*time.Millisecond, attempt.Duration())
+
+	// 沒有登記過的挑戰不會被記錄
+	history.Finish(today, game.Medium, true, time.Second)
+	assert.False(t, history.HasAttempt(today, game.Medium))
+}
+
+func TestRecentAndPersist(t *testing.T) {
+	store := storage.Dir(t.TempDir())
+	history, err := Load(store)
+	require.NoError(t, err)
+	for day := 1; day <= 3; day++ {
+		history.Start(time.Date(2026, 10, day, 12, 0, 0, 0, time.Local), game.Easy, int64(day))
+	}
+	history.Start(time.Date(2026, 10, 3, 12, 0, 0, 0, time.Local), game.Medium, 4)
+	require.NoError(t, history.Save())
+
+	loaded, err := Load(store)
+	require.NoError(t, err)
+	recent := loaded.Recent(3)
+	require.Len(t, recent, 3)
+	assert.Equal(t, Attempt{Date: "2026-10-03", Level: game.Easy, Seed: 3}, recent[0])
+	assert.Equal(t, Attempt{Date: "2026-10-03", Level: game.Medium, Seed: 4}, recent[1])
+	assert.Equal(t, "2026-10-02", recent[2].Date)
+}
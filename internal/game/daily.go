@@ -0,0 +1,23 @@
+package game
+
+import (
+	"fmt"
+	"hash/fnv"
+	"time"
+)
+
+// DailyDateFormat - 每日挑戰以當地日曆日期區分
+const DailyDateFormat = "2006-01-02"
+
+// DailySeed - 由日期與難度決定 seed，同一天同難度的所有玩家都會得到相同盤面
+func DailySeed(date time.Time, level Level) int64 {
+	hash := fnv.New64a()
+	fmt.Fprintf(hash, "mine-sweeper-daily/%s/%d", date.Format(DailyDateFormat), level)
+	return int64(hash.Sum64())
+}
+
+// NewDailyGame - 建立該日期與難度的每日挑戰盤面
+func NewDailyGame(date time.Time, level Level) *Game {
+	setup := LevelSetupMap[level]
+	return NewGameWithSeed(setup.Rows, setup.Cols, setup.MineCounts, DailySeed(date, level))
+}
@@ -0,0 +1,50 @@
+package game
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDailyGame(t *testing.T) {
+	morning := time.Date(2026, 10, 19, 8, 0, 0, 0, time.Local)
+	evening := time.Date(2026, 10, 19, 23, 0, 0, 0, time.Local)
+	tomorrow := time.Date(2026, 10, 20, 8, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name      string
+		first     *Game
+		second    *Game
+		wantEqual bool
+	}{
+		{
+			name:      "same date and level share a board",
+			first:     NewDailyGame(morning, Easy),
+			second:    NewDailyGame(evening, Easy),
+			wantEqual: true,
+		},
+		{
+			name:   "different date",
+			first:  NewDailyGame(morning, Easy),
+			second: NewDailyGame(tomorrow, Easy),
+		},
+		{
+			name:   "different level",
+			first:  NewDailyGame(morning, Easy),
+			second: NewDailyGame(morning, Medium),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.wantEqual, tt.first.Seed == tt.second.Seed)
+			if tt.wantEqual {
+				assert.Equal(t, tt.first.Board.cells, tt.second.Board.cells)
+			}
+		})
+	}
+	setup := LevelSetupMap[Medium]
+	game := NewDailyGame(morning, Medium)
+	assert.Equal(t, setup.Rows, game.Board.Rows)
+	assert.Equal(t, setup.MineCounts, len(game.Board.mineCoords))
+}
@@ -0,0 +1,38 @@
+package game
+
+// EventType - 遊戲事件種類
+type EventType int
+
+const (
+	EventReveal  EventType = iota // 翻開格子 (包含 flood fill 連帶翻開的格子)
+	EventFlag                     // 插旗
+	EventUnflag                   // 取消插旗
+	EventExplode                  // 踩到地雷，遊戲失敗
+	EventWin                      // 所有安全格子都已翻開
+	EventChord                    // 在數字格上同時翻開周圍所有未插旗的格子
+	EventHint                     // 玩家使用提示，Row/Col 為提示的格子
+)
+
+// Event - 遊戲核心發出的事件，供統計、音效、動畫等外部模組使用
+type Event struct {
+	Type  EventType
+	Row   int
+	Col   int
+	Cells int          // EventReveal 時本次翻開的格子數
+	Steps []RevealStep // EventReveal 時依照 BFS 順序翻開的格子，供動畫使用
+}
+
+// EventListener - 接收遊戲事件
+type EventListener func(event Event)
+
+// AddListener - 註冊事件監聽者，事件會依註冊順序同步通知
+func (g *Game) AddListener(listener EventListener) {
+	g.listeners = append(g.listeners, listener)
+}
+
+// emit - 通知所有監聽者
+func (g *Game) emit(event Event) {
+	for _, listener := range g.listeners {
+		listener(event)
+	}
+}
@@ -0,0 +1,265 @@
+package game
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGameEvents(t *testing.T) {
+	newGame := func() *Game {
+		game := NewGame(3, 3, 1)
+		game.Init(&Board{
+			Rows: 3,
+			Cols: 3,
+			cells: [][]*Cell{
+				{{IsMine: true}, {AdjacenetMines: 1}, {}},
+				{{AdjacenetMines: 1}, {AdjacenetMines: 1}, {}},
+				{{}, {}, {}},
+			},
+		}, positionShuffler(func(coords []coord) {}))
+		game.Board.mineCoords = []coord{{Row: 0, Col: 0}}
+		return game
+	}
+	tests := []struct {
+		name string
+		play func(game *Game)
+		want []Event
+	}{
+		{
+			name: "flag then unflag",
+			play: func(game *Game) {
+				game.ToggleFlag(0, 0)
+				game.ToggleFlag(0, 0)
+				game.ToggleFlag(-1, 0)
+			},
+			want: []Event{
+				{Type: EventFlag, Row: 0, Col: 0},
+				{Type: EventUnflag, Row: 0, Col: 0},
+			},
+		},
+		{
+			name: "flood fill wins the game",
+			play: func(game *Game) {
+				game.RevealCell(2, 2)
+				game.RevealCell(2, 2)
+			},
+			want: []Event{
+				{Type: EventReveal, Row: 2, Col: 2, Cells: 8, Steps: []RevealStep{
+					{Position: Position{Row: 2, Col: 2}, Depth: 0},
+					{Position: Position{Row: 1, Col: 1}, Depth: 1},
+					{Position: Position{Row: 1, Col: 2}, Depth: 1},
+					{Position: Position{Row: 2, Col: 1}, Depth: 1},
+					{Position: Position{Row: 0, Col: 1}, Depth: 2},
+					{Position: Position{Row: 0, Col: 2}, Depth: 2},
+					{Position: Position{Row: 1, Col: 0}, Depth: 2},
+					{Position: Position{Row: 2, Col: 0}, Depth: 2},
+				}},
+				{Type: EventWin, Row: 2, Col: 2},
+			},
+		},
+		{
+			name: "reveal mine explodes",
+			play: func(game *Game) {
+				game.RevealCell(1, 1)
+				game.RevealCell(0, 0)
+			},
+			want: []Event{
+				{Type: EventReveal, Row: 1, Col: 1, Cells: 1, Steps: []RevealStep{{Position: Position{Row: 1, Col: 1}}}},
+				{Type: EventReveal, Row: 0, Col: 0, Cells: 1, Steps: []RevealStep{{Position: Position{Row: 0, Col: 0}}}},
+				{Type: EventExplode, Row: 0, Col: 0},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newGame()
+			var events []Event
+			game.AddListener(func(event Event) {
+				events = append(events, event)
+			})
+			tt.play(game)
+			assert.Equal(t, tt.want, events)
+		})
+	}
+}
+
+func TestChord(t *testing.T) {
+	// 地雷在 (0,0)，(1,1) 的數字為 1
+	newGame := func() *Game {
+		game := NewGame(3, 3, 1)
+		game.Init(&Board{
+			Rows: 3,
+			Cols: 3,
+			cells: [][]*Cell{
+				{{IsMine: true}, {AdjacenetMines: 1}, {}},
+				{{AdjacenetMines: 1}, {AdjacenetMines: 1}, {}},
+				{{}, {}, {}},
+			},
+		}, positionShuffler(func(coords []coord) {}))
+		game.Board.mineCoords = []coord{{Row: 0, Col: 0}}
+		game.RevealCell(1, 1)
+		return game
+	}
+	tests := []struct {
+		name         string
+		flag         [2]int
+		chordAt      [2]int
+		wantChord    bool
+		wantGameOver bool
+		wantWin      bool
+	}{
+		{
+			name:      "correct flag reveals the rest",
+			flag:      [2]int{0, 0},
+			chordAt:   [2]int{1, 1},
+			wantChord: true,
+			wantWin:   true,
+		},
+		{
+			name:         "wrong flag explodes",
+			flag:         [2]int{2, 2},
+			chordAt:      [2]int{1, 1},
+			wantChord:    true,
+			wantGameOver: true,
+		},
+		{
+			name:    "chord on hidden cell does nothing",
+			flag:    [2]int{0, 0},
+			chordAt: [2]int{2, 2},
+		},
+		{
+			name:    "flag count mismatch does nothing",
+			flag:    [2]int{-1, -1},
+			chordAt: [2]int{1, 1},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := newGame()
+			game.ToggleFlag(tt.flag[0], tt.flag[1])
+			assert.Equal(t, tt.wantChord, game.Chord(tt.chordAt[0], tt.chordAt[1]))
+			assert.Equal(t, tt.wantGameOver, game.IsGameOver)
+			assert.Equal(t, tt.wantWin, game.IsPlayerWin)
+		})
+	}
+}
+
+func TestExploded(t *testing.T) {
+	tests := []struct {
+		name         string
+		play         func(game *Game)
+		wantExploded bool
+		want         Position
+		wantBefore   []string
+	}{
+		{
+			name: "safe reveal does not explode",
+			play: func(game *Game) { game.RevealCell(2, 2) },
+		},
+		{
+			name:         "revealing a mine",
+			play:         func(game *Game) { game.RevealCell(0, 0) },
+			wantExploded: true,
+			want:         Position{Row: 0, Col: 0},
+			wantBefore:   []string{"###", "###", "###"},
+		},
+		{
+			name: "chord with a wrong flag",
+			play: func(game *Game) {
+				game.RevealCell(1, 0)
+				game.ToggleFlag(2, 0)
+				game.Chord(1, 0)
+			},
+			wantExploded: true,
+			want:         Position{Row: 0, Col: 0},
+			wantBefore:   []string{"###", "1##", "F##"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := LoadGame(strings.NewReader("*..\n...\n...\n"))
+			require.NoError(t, err)
+			tt.play(game)
+			position, exploded := game.Exploded()
+			assert.Equal(t, tt.wantExploded, exploded)
+			assert.Equal(t, tt.want, position)
+			assert.Equal(t, tt.wantBefore, game.BeforeLoss())
+		})
+	}
+}
+
+func TestUseHint(t *testing.T) {
+	tests := []struct {
+		name        string
+		finish      bool
+		penalties   []time.Duration
+		wantHints   int
+		wantPenalty time.Duration
+	}{
+		{name: "no hint", wantPenalty: 0},
+		{
+			name:        "penalties add up",
+			penalties:   []time.Duration{10 * time.Second, 5 * time.Second},
+			wantHints:   2,
+			wantPenalty: 15 * time.Second,
+		},
+		{
+			name:        "negative penalty is ignored",
+			penalties:   []time.Duration{-time.Second},
+			wantHints:   1,
+			wantPenalty: 0,
+		},
+		{
+			name:        "finished game ignores hints",
+			finish:      true,
+			penalties:   []time.Duration{10 * time.Second},
+			wantPenalty: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := LoadGame(strings.NewReader("*.\n..\n"))
+			require.NoError(t, err)
+			if tt.finish {
+				game.RevealCell(0, 0)
+			}
+			var events []Event
+			game.AddListener(func(event Event) { events = append(events, event) })
+			for _, penalty := range tt.penalties {
+				game.UseHint(1, 1, penalty)
+			}
+			assert.Equal(t, tt.wantHints, game.Hints)
+			assert.Equal(t, tt.wantHints > 0, game.Assisted())
+			assert.Len(t, events, tt.wantHints)
+			// 扣掉實際經過的時間 (測試執行時間遠小於一秒)
+			assert.Equal(t, tt.wantPenalty, game.GetElapsedDuration().Truncate(time.Second))
+		})
+	}
+}
+
+func TestPauseTimer(t *testing.T) {
+	game, err := LoadGame(strings.NewReader("*.\n..\n"))
+	require.NoError(t, err)
+	game.startTime = time.Now().UTC().Add(-10 * time.Second)
+
+	game.Pause()
+	assert.True(t, game.Paused())
+	// 模擬暫停了 5 秒：暫停期間不計時
+	game.pausedAt = game.pausedAt.Add(-5 * time.Second)
+	assert.Equal(t, 5*time.Second, game.GetElapsedDuration().Truncate(time.Second))
+	game.Resume()
+	assert.False(t, game.Paused())
+	assert.Equal(t, 5*time.Second, game.GetElapsedDuration().Truncate(time.Second))
+
+	// 暫停中結束的遊戲以結束前的計時為準
+	game.Pause()
+	game.RevealCell(0, 0)
+	assert.False(t, game.Paused())
+	assert.Equal(t, 5*time.Second, game.GetElapsedDuration().Truncate(time.Second))
+	game.Pause()
+	assert.False(t, game.Paused(), "finished games cannot be paused")
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// commitmentVersion - 承諾雜湊內容的格式版本
+const commitmentVersion = "mine-sweeper-commitment/v1"
+
+// Proof - 遊戲結束後公開的資料，任何人都可以用來驗證盤面在遊戲中沒有被更動
+type Proof struct {
+	Commitment string   // 遊戲開始前公開的承諾雜湊
+	Seed       int64    // 地雷配置使用的 seed，0 代表盤面不是由 seed 產生
+	Salt       string   // 承諾雜湊的隨機值
+	Layout     []string // 地雷配置，格式與 LoadGame 的盤面檔案相同
+}
+
+// Verify - 以公開的 seed、salt 與地雷配置重新計算承諾雜湊並比對
+func (p Proof) Verify() bool {
+	return Commit(p.Seed, p.Salt, p.Layout) == p.Commitment
+}
+
+// Commit - 對 seed 與地雷配置計算 SHA-256 承諾雜湊
+//
+// salt 是遊戲開始時產生的隨機值，避免只從雜湊暴力搜尋 seed 就推算出盤面
+func Commit(seed int64, salt string, layout []string) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\nsalt=%s\nseed=%d\n", commitmentVersion, salt, seed)
+	for _, line := range layout {
+		fmt.Fprintln(hash, line)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// VerifySeed - 以 seed 重新產生盤面並比對承諾雜湊，回傳重新產生的地雷配置
+func VerifySeed(commitment string, seed int64, salt string, rows, cols, mineCount int) ([]string, bool) {
+	layout := NewGameWithSeed(rows, cols, mineCount, seed).Board.Layout()
+	return layout, Commit(seed, salt, layout) == commitment
+}
+
+// Layout - 地雷配置，每列一個字串，LayoutMine 為地雷、LayoutSafe 為安全的格子
+func (b *Board) Layout() []string {
+	rows := make([]string, b.Rows)
+	for row := range b.cells {
+		line := make([]byte, b.Cols)
+		for col, cell := range b.cells[row] {
+			line[col] = LayoutSafe
+			if cell.IsMine {
+				line[col] = LayoutMine
+			}
+		}
+		rows[row] = string(line)
+	}
+	return rows
+}
+
+// commit - 放置地雷後產生 salt 與承諾雜湊
+func (g *Game) commit() {
+	g.salt = rand.Text()
+	g.commitment = Commit(g.Seed, g.salt, g.Board.Layout())
+}
+
+// Commitment - 遊戲開始前就可以公開的承諾雜湊
+func (g *Game) Commitment() string {
+	return g.commitment
+}
+
+// Proof - 遊戲結束後公開 seed、salt 與地雷配置，遊戲進行中回傳 false
+func (g *Game) Proof() (Proof, bool) {
+	if !g.IsGameOver && !g.IsPlayerWin {
+		return Proof{}, false
+	}
+	return Proof{
+		Commitment: g.commitment,
+		Seed:       g.Seed,
+		Salt:       g.salt,
+		Layout:     g.Board.Layout(),
+	}, true
+}
@@ -0,0 +1,84 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCommitment(t *testing.T) {
+	game := NewGameWithSeed(9, 9, 10, 42)
+	require.Len(t, game.Commitment(), 64)
+	// 同一個 seed 每局的 salt 不同，雜湊也不同
+	assert.NotEqual(t, game.Commitment(), NewGameWithSeed(9, 9, 10, 42).Commitment())
+
+	_, revealed := game.Proof()
+	assert.False(t, revealed, "seed must stay hidden while playing")
+
+	position := game.Board.mineCoords[0]
+	game.RevealCell(position.Row, position.Col)
+	proof, revealed := game.Proof()
+	require.True(t, revealed)
+	assert.Equal(t, int64(42), proof.Seed)
+	assert.True(t, proof.Verify())
+
+	layout, ok := VerifySeed(proof.Commitment, proof.Seed, proof.Salt, 9, 9, 10)
+	assert.True(t, ok)
+	assert.Equal(t, proof.Layout, layout)
+}
+
+func TestVerifyRejectsTampering(t *testing.T) {
+	game := NewGameWithSeed(9, 9, 10, 42)
+	game.RevealCell(game.Board.mineCoords[0].Row, game.Board.mineCoords[0].Col)
+	proof, _ := game.Proof()
+
+	tests := []struct {
+		name   string
+		tamper func(proof *Proof)
+	}{
+		{name: "different seed", tamper: func(proof *Proof) { proof.Seed++ }},
+		{name: "different salt", tamper: func(proof *Proof) { proof.Salt += "x" }},
+		{
+			name: "moved mine",
+			tamper: func(proof *Proof) {
+				layout := append([]string(nil), proof.Layout...)
+				layout[0] = strings.Map(func(ch rune) rune {
+					if ch == LayoutMine {
+						return LayoutSafe
+					}
+					return LayoutMine
+				}, layout[0])
+				proof.Layout = layout
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tampered := proof
+			tt.tamper(&tampered)
+			assert.False(t, tampered.Verify())
+		})
+	}
+	_, ok := VerifySeed(proof.Commitment, proof.Seed+1, proof.Salt, 9, 9, 10)
+	assert.False(t, ok)
+}
+
+func TestLoadedGameCommitment(t *testing.T) {
+	game, err := LoadGame(strings.NewReader("*..\n...\n"))
+	require.NoError(t, err)
+	game.RevealCell(0, 0)
+	proof, revealed := game.Proof()
+	require.True(t, revealed)
+	assert.Equal(t, []string{"*..", "..."}, proof.Layout)
+	assert.True(t, proof.Verify())
+}
+
+func TestNewGameSeed(t *testing.T) {
+	first, second := NewGame(9, 9, 10), NewGame(9, 9, 10)
+	assert.Positive(t, first.Seed)
+	assert.NotEqual(t, first.Seed, second.Seed)
+	// seed 必須能重現同一個盤面，驗證時才能從 seed 重新產生
+	assert.Equal(t, first.Board.Layout(), NewGameWithSeed(9, 9, 10, first.Seed).Board.Layout())
+}
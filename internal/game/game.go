@@ -12,13 +12,13 @@ type Cell struct {
 
 // Board - 棋盤
 type Board struct {
-	Rows                     int              // 總共格數
-	Cols                     int              // 總共列數
-	cells                    [][]*Cell        // 整格棋盤狀態
-	minePositionShuffler     positionShuffler // 亂序器用來安排地雷格子
-	remainingFlags           int              // 剩餘標記數
-	mineCoords               []coord          // 紀錄被設定成 mines 的座標
-	remainingUnRevealedCells int              // 剩餘需要翻開的格子數
+	Rows                     int        // 總共格數
+	Cols                     int        // 總共列數
+	cells                    [][]*Cell  // 整格棋盤狀態
+	minePlacer               MinePlacer // 地雷配置策略
+	remainingFlags           int        // 剩餘標記數
+	mineCoords               []coord    // 紀錄被設定成 mines 的座標
+	remainingUnRevealedCells int        // 剩餘需要翻開的格子數
 }
 
 // Game - 遊戲物件
@@ -27,7 +27,29 @@ type Game struct {
 	IsGameOver  bool      // 是否遊戲結束
 	IsPlayerWin bool      // 玩家是否獲勝
 	startTime   time.Time // 遊戲開始時間
+	endTime     time.Time // 遊戲結束時間
+	pausedAt    time.Time // 暫停計時的時間，零值代表沒有暫停
 	MineCounts  int       // minecounts
+	Seed        int64     // 地雷配置使用的 seed，0 代表未指定
+	exploded    *Position // 踩到的地雷，nil 代表還沒有踩到
+	beforeLoss  []string  // 致命一擊之前的可見盤面，用來分析失敗是否可以避免
+	Hints       int       // 使用提示的次數
+	salt        string    // 承諾雜湊的隨機值，遊戲結束後與 seed 一起公開
+	commitment  string    // 遊戲開始前公開的承諾雜湊
+	penalty     time.Duration
+	listeners   []EventListener
+}
+
+// Position - 盤面上的格子座標，提供給外部模組使用
+type Position struct {
+	Row int
+	Col int
+}
+
+// RevealStep - flood fill 翻開的一格，Depth 為與起點的 BFS 距離
+type RevealStep struct {
+	Position
+	Depth int
 }
 
 // coord - 紀錄該格字座標
@@ -36,22 +58,37 @@ type coord struct {
 	Col int
 }
 
-// positionShuffler - 亂序器用來安排地雷格子
-type positionShuffler func(coords []coord)
-
+// NewGame - 以 crypto/rand 產生的 seed 建立遊戲，seed 會記錄在 Game.Seed 以便重現盤面
 func NewGame(rows, cols, mineCount int) *Game {
+	return NewGameWithSeed(rows, cols, mineCount, randomSeed())
+}
+
+// NewGameWithSeed - 以固定 seed 建立遊戲，相同參數與 seed 會得到相同盤面
+func NewGameWithSeed(rows, cols, mineCount int, seed int64) *Game {
+	return newGameWithPlacer(rows, cols, mineCount, NewSeededPlacer(seed), seed)
+}
+
+// NewGameWithPlacer - 以指定的地雷配置策略建立遊戲，策略回傳的有效位置少於 mineCount 時以實際放置的地雷數為準
+func NewGameWithPlacer(rows, cols, mineCount int, placer MinePlacer) *Game {
+	return newGameWithPlacer(rows, cols, mineCount, placer, 0)
+}
+
+// newGameWithPlacer - 放置地雷後立刻對 seed 與地雷配置產生承諾雜湊
+func newGameWithPlacer(rows, cols, mineCount int, placer MinePlacer, seed int64) *Game {
 	board := NewBoard(rows, cols, mineCount)
-	// 設定地雷
+	board.minePlacer = placer
 	board.PlaceMines(mineCount)
-	// 計算結果
 	board.CalculateAdjacentMines()
-	return &Game{
+	game := &Game{
 		Board:       board,
 		IsGameOver:  false,
 		IsPlayerWin: false,
 		startTime:   time.Now().UTC(),
-		MineCounts:  mineCount,
+		MineCounts:  len(board.mineCoords),
+		Seed:        seed,
 	}
+	game.commit()
+	return game
 }
 
 // NewBoard - 初始化盤面
@@ -59,7 +96,7 @@ func NewBoard(rows, cols, mineCount int) *Board {
 	board := &Board{
 		Rows:                     rows,
 		Cols:                     cols,
-		minePositionShuffler:     defaultPositionShuffler,
+		minePlacer:               defaultPositionShuffler,
 		remainingFlags:           mineCount,
 		remainingUnRevealedCells: rows*cols - mineCount,
 	}
@@ -73,9 +110,10 @@ func NewBoard(rows, cols, mineCount int) *Board {
 	return board
 }
 
-func (g *Game) Init(board *Board, minePositionShuffler positionShuffler) {
-	if minePositionShuffler != nil {
-		g.Board.minePositionShuffler = minePositionShuffler
+// Init - 以 board 的格子狀態覆蓋目前的盤面，placer 不為 nil 時一併替換地雷配置策略
+func (g *Game) Init(board *Board, placer MinePlacer) {
+	if placer != nil {
+		g.Board.minePlacer = placer
 	}
 	// 無效的設定
 	if board == nil || len(board.cells) != board.Rows || len(board.cells[0]) != board.Cols {
@@ -93,30 +131,30 @@ func (g *Game) Init(board *Board, minePositionShuffler positionShuffler) {
 	}
 }
 
-// PlaceMines - 使用 minePositionShuffler 選出 mineCount 個地雷
+// PlaceMines - 使用 minePlacer 選出 mineCount 個地雷，超出邊界或重複的位置會被略過
 func (b *Board) PlaceMines(mineCount int) {
 	if mineCount < 0 {
 		return
 	}
-	// 蒐集所有 coord
-	coords := make([]coord, 0, b.Cols*b.Rows)
-	for row := range b.cells {
-		for col := range b.cells[row] {
-			coords = append(coords, coord{Row: row, Col: col})
+	// 避免 mineCount 超過格子個數
+	mineCount = min(mineCount, b.Rows*b.Cols)
+	placed := 0
+	for _, position := range b.minePlacer.Place(b.Rows, b.Cols, mineCount) {
+		if placed == mineCount {
+			break
 		}
+		if position.Row < 0 || position.Row >= b.Rows ||
+			position.Col < 0 || position.Col >= b.Cols ||
+			b.cells[position.Row][position.Col].IsMine {
+			continue
+		}
+		b.cells[position.Row][position.Col].IsMine = true
+		b.mineCoords = append(b.mineCoords, coord{Row: position.Row, Col: position.Col})
+		placed++
 	}
-	// 使用 minePositionShuffler 作洗牌
-	b.minePositionShuffler(coords)
-	coordLen := len(coords)
-	// 避免 mineCount 超過 coords 個數
-	if mineCount > coordLen {
-		mineCount = coordLen
-	}
-	// 設定前 mineCount 為地雷
-	for i := 0; i < mineCount; i++ {
-		b.cells[coords[i].Row][coords[i].Col].IsMine = true
-		b.mineCoords = append(b.mineCoords, coords[i])
-	}
+	// 策略給的位置不足時，修正剩餘旗子與需要翻開的格子數
+	b.remainingFlags -= mineCount - placed
+	b.remainingUnRevealedCells += mineCount - placed
 }
 
 // CalculateAdjacentMines - 計算鄰近地雷個數
@@ -179,12 +217,17 @@ func (board *Board) ToggleFlag(row, col int) {
 	board.cells[row][col].Flagged = !cell.Flagged
 }
 
-// Reveal - 從 row, col 開始翻開周圍不是地雷，直到遇到非零的格子
-func (board *Board) Reveal(row, col int) {
-	visitQueue := []coord{{
+// Reveal - 從 row, col 開始翻開周圍不是地雷，直到遇到非零的格子，回傳依照 BFS 順序翻開的格子
+func (board *Board) Reveal(row, col int) []RevealStep {
+	type visit struct {
+		coord
+		depth int
+	}
+	visitQueue := []visit{{coord: coord{
 		Row: row,
 		Col: col,
-	}}
+	}}}
+	var steps []RevealStep
 	// 透過 queue 來實做 BFS
 	for len(visitQueue) > 0 {
 		// pop up first
@@ -207,13 +250,14 @@ func (board *Board) Reveal(row, col int) {
 		// 標注該格已經被揭開
 		board.cells[curRow][curCol].Revealed = true
 		board.remainingUnRevealedCells--
+		steps = append(steps, RevealStep{Position: Position{Row: curRow, Col: curCol}, Depth: cellCoord.depth})
 		if cell.Flagged {
 			board.remainingFlags++
 			board.cells[curRow][curCol].Flagged = false
 		}
 		if cell.IsMine {
 			board.revealMines()
-			return
+			return steps
 		}
 		// 如果是空白格 (AdjacenetMines = 0, 且不是地雷)
 		if !cell.IsMine && cell.AdjacenetMines == 0 {
@@ -225,13 +269,23 @@ func (board *Board) Reveal(row, col int) {
 			}
 			for _, direction := range neighborDirections {
 				neighborRow, neighborCol := curRow+direction.Row, curCol+direction.Col
-				visitQueue = append(visitQueue, coord{
+				visitQueue = append(visitQueue, visit{coord: coord{
 					Row: neighborRow,
 					Col: neighborCol,
-				})
+				}, depth: cellCoord.depth + 1})
 			}
 		}
 	}
+	return steps
+}
+
+// Mines - 所有地雷的座標
+func (board *Board) Mines() []Position {
+	mines := make([]Position, 0, len(board.mineCoords))
+	for _, mineCoord := range board.mineCoords {
+		mines = append(mines, Position{Row: mineCoord.Row, Col: mineCoord.Col})
+	}
+	return mines
 }
 
 // revealMines - 顯示所有 Mines
@@ -257,7 +311,176 @@ func (board *Board) GetRemainingFlags() int {
 	return board.remainingFlags
 }
 
-// GetElapsedTime - 取出從 startTime 之後到目前為止的時間
+// GetElapsedTime - 取出從 startTime 之後到目前為止的時間，遊戲結束後固定為結束時的時間
 func (g *Game) GetElapsedTime() int {
-	return int(time.Since(g.startTime).Seconds())
+	return int(g.GetElapsedDuration().Seconds())
+}
+
+// GetElapsedDuration - 與 GetElapsedTime 相同但保留毫秒精度，用於排行榜比較，包含提示的懲罰時間
+func (g *Game) GetElapsedDuration() time.Duration {
+	if !g.endTime.IsZero() {
+		return g.endTime.Sub(g.startTime) + g.penalty
+	}
+	if !g.pausedAt.IsZero() {
+		return g.pausedAt.Sub(g.startTime) + g.penalty
+	}
+	return time.Since(g.startTime) + g.penalty
+}
+
+// Pause - 暫停計時，例如開啟選單時，已暫停或遊戲結束時不做任何事
+func (g *Game) Pause() {
+	if g.pausedAt.IsZero() && g.endTime.IsZero() {
+		g.pausedAt = time.Now().UTC()
+	}
+}
+
+// Resume - 繼續計時，暫停的時間不計入經過時間
+func (g *Game) Resume() {
+	if g.pausedAt.IsZero() {
+		return
+	}
+	g.startTime = g.startTime.Add(time.Since(g.pausedAt))
+	g.pausedAt = time.Time{}
+}
+
+// Paused - 是否暫停計時中
+func (g *Game) Paused() bool {
+	return !g.pausedAt.IsZero()
+}
+
+// UseHint - 記錄玩家在 row, col 使用提示，經過時間加上 penalty
+func (g *Game) UseHint(row, col int, penalty time.Duration) {
+	if g.IsGameOver || g.IsPlayerWin {
+		return
+	}
+	g.Hints++
+	g.penalty += max(penalty, 0)
+	g.emit(Event{Type: EventHint, Row: row, Col: col})
+}
+
+// Assisted - 是否使用過提示
+func (g *Game) Assisted() bool {
+	return g.Hints > 0
+}
+
+// RevealCell - 翻開 row, col 格子並更新遊戲勝負狀態
+func (g *Game) RevealCell(row, col int) {
+	// 遊戲已結束或超出邊界
+	if g.IsGameOver || g.IsPlayerWin ||
+		row < 0 || row >= g.Board.Rows ||
+		col < 0 || col >= g.Board.Cols {
+		return
+	}
+	cell := g.Board.GetCell(row, col)
+	if cell.Revealed {
+		return
+	}
+	// 檢查是否踩到地雷
+	if cell.IsMine {
+		g.rememberBeforeLoss()
+		g.IsGameOver = true
+		g.exploded = &Position{Row: row, Col: col}
+	}
+	// 執行 Flood Fill - 更新踩到之後的更新
+	remainingBefore := g.Board.remainingUnRevealedCells
+	steps := g.Board.Reveal(row, col)
+	// 檢查是否達到勝利條件
+	if !g.IsGameOver {
+		g.IsPlayerWin = g.Board.CheckIsPlayerWin()
+	}
+	if g.IsGameOver || g.IsPlayerWin {
+		g.Resume()
+		g.endTime = time.Now().UTC()
+	}
+	g.emit(Event{Type: EventReveal, Row: row, Col: col, Cells: remainingBefore - g.Board.remainingUnRevealedCells, Steps: steps})
+	if g.IsGameOver {
+		g.emit(Event{Type: EventExplode, Row: row, Col: col})
+	}
+	if g.IsPlayerWin {
+		g.emit(Event{Type: EventWin, Row: row, Col: col})
+	}
+}
+
+// Exploded - 踩到的地雷位置，還沒有踩到時回傳 false
+func (g *Game) Exploded() (Position, bool) {
+	if g.exploded == nil {
+		return Position{}, false
+	}
+	return *g.exploded, true
+}
+
+// rememberBeforeLoss - 踩到地雷前記下可見盤面，chord 時以 chord 之前的盤面為準
+func (g *Game) rememberBeforeLoss() {
+	if g.beforeLoss == nil {
+		g.beforeLoss = g.Board.VisibleRows()
+	}
+}
+
+// BeforeLoss - 致命一擊之前的可見盤面，還沒有踩到地雷時回傳 nil
+func (g *Game) BeforeLoss() []string {
+	return g.beforeLoss
+}
+
+// Chord - 當已翻開數字格周圍的旗子數等於數字時，翻開周圍所有未插旗的格子，回傳是否有執行
+func (g *Game) Chord(row, col int) bool {
+	// 遊戲已結束或超出邊界
+	if g.IsGameOver || g.IsPlayerWin ||
+		row < 0 || row >= g.Board.Rows ||
+		col < 0 || col >= g.Board.Cols {
+		return false
+	}
+	cell := g.Board.GetCell(row, col)
+	if !cell.Revealed || cell.IsMine || cell.AdjacenetMines == 0 {
+		return false
+	}
+	// 計算周圍旗子數與尚未翻開的格子
+	flags := 0
+	var hidden []coord
+	for _, direction := range neighborDirections {
+		neighborRow, neighborCol := row+direction.Row, col+direction.Col
+		if neighborRow < 0 || neighborRow >= g.Board.Rows ||
+			neighborCol < 0 || neighborCol >= g.Board.Cols {
+			continue
+		}
+		neighbor := g.Board.GetCell(neighborRow, neighborCol)
+		switch {
+		case neighbor.Flagged:
+			flags++
+		case !neighbor.Revealed:
+			hidden = append(hidden, coord{Row: neighborRow, Col: neighborCol})
+		}
+	}
+	if flags != cell.AdjacenetMines || len(hidden) == 0 {
+		return false
+	}
+	// 旗子插錯時 chord 會踩到地雷，先記下 chord 之前的盤面
+	for _, position := range hidden {
+		if g.Board.GetCell(position.Row, position.Col).IsMine {
+			g.rememberBeforeLoss()
+			break
+		}
+	}
+	g.emit(Event{Type: EventChord, Row: row, Col: col, Cells: len(hidden)})
+	for _, position := range hidden {
+		g.RevealCell(position.Row, position.Col)
+	}
+	return true
+}
+
+// ToggleFlag - 在遊戲進行中標記或取消標記 row, col 格子
+func (g *Game) ToggleFlag(row, col int) {
+	// 遊戲已結束或超出邊界
+	if g.IsGameOver || g.IsPlayerWin ||
+		row < 0 || row >= g.Board.Rows ||
+		col < 0 || col >= g.Board.Cols {
+		return
+	}
+	wasFlagged := g.Board.GetCell(row, col).Flagged
+	g.Board.ToggleFlag(row, col)
+	switch isFlagged := g.Board.GetCell(row, col).Flagged; {
+	case isFlagged && !wasFlagged:
+		g.emit(Event{Type: EventFlag, Row: row, Col: col})
+	case !isFlagged && wasFlagged:
+		g.emit(Event{Type: EventUnflag, Row: row, Col: col})
+	}
 }
@@ -13,12 +13,12 @@ func TestGameRestart(t *testing.T) {
 		mineCount = 5
 	)
 
-	predicableMineShuffler := func(coords []coord) {
+	predicableMineShuffler := positionShuffler(func(coords []coord) {
 		// not shuffler
-	}
+	})
 	newGameWithPredictableMines := func() *Game {
 		game := NewGame(rows, cols, mineCount)
-		game.Board.minePositionShuffler = predicableMineShuffler
+		game.Board.minePlacer = predicableMineShuffler
 		game.Board.cells = make([][]*Cell, rows)
 		for r := range game.Board.cells {
 			game.Board.cells[r] = make([]*Cell, cols)
@@ -47,8 +47,8 @@ func TestGameRestart(t *testing.T) {
 
 	// 5 Simulate a restart by create a new game
 	restartedGame := NewGame(rows, cols, mineCount)
-	restartedGame.Board.minePositionShuffler = predicableMineShuffler
-	restartedGame.Board.minePositionShuffler = predicableMineShuffler
+	restartedGame.Board.minePlacer = predicableMineShuffler
+	restartedGame.Board.minePlacer = predicableMineShuffler
 	restartedGame.Board.cells = make([][]*Cell, rows)
 	for r := range restartedGame.Board.cells {
 		restartedGame.Board.cells[r] = make([]*Cell, cols)
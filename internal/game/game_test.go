@@ -362,7 +362,7 @@ func TestGameInit(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			game := NewGame(tt.input.rows, tt.input.cols, tt.input.minesNumber)
-			game.Init(tt.input.board, func(coords []coord) {})
+			game.Init(tt.input.board, positionShuffler(func(coords []coord) {}))
 			assert.Equal(t, tt.want.cells, game.Board.cells)
 		})
 	}
@@ -387,9 +387,9 @@ func TestCalculateAdjacentMines(t *testing.T) {
 				cols:        5,
 				minesNumber: 4,
 				board: &Board{
-					Rows:                 5,
-					Cols:                 5,
-					minePositionShuffler: func(coords []coord) {},
+					Rows:       5,
+					Cols:       5,
+					minePlacer: positionShuffler(func(coords []coord) {}),
 					cells: [][]*Cell{
 						{
 							{
@@ -480,9 +480,9 @@ func TestCalculateAdjacentMines(t *testing.T) {
 				},
 			},
 			want: &Board{
-				Rows:                 5,
-				Cols:                 5,
-				minePositionShuffler: func(coords []coord) {},
+				Rows:       5,
+				Cols:       5,
+				minePlacer: positionShuffler(func(coords []coord) {}),
 				cells: [][]*Cell{
 					{
 						{
@@ -601,7 +601,7 @@ func TestCalculateAdjacentMines(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			game := NewGame(tt.input.rows, tt.input.cols, tt.input.minesNumber)
-			game.Init(tt.input.board, func(coords []coord) {})
+			game.Init(tt.input.board, positionShuffler(func(coords []coord) {}))
 			game.Board.CalculateAdjacentMines()
 			assert.Equal(t, tt.want.cells, game.Board.cells)
 		})
@@ -0,0 +1,54 @@
+package game
+
+import (
+	"fmt"
+	"strings"
+)
+
+type Level int
+
+const (
+	Easy Level = iota
+	Medium
+	Hard
+)
+
+type LevelSetup struct {
+	Rows       int
+	Cols       int
+	MineCounts int
+}
+
+var LevelSetupMap map[Level]LevelSetup = map[Level]LevelSetup{
+	Easy: LevelSetup{
+		9,
+		9,
+		10,
+	},
+	Medium: LevelSetup{
+		16,
+		16,
+		40,
+	},
+	Hard: LevelSetup{
+		30,
+		16,
+		99,
+	},
+}
+
+var LevelMessage map[Level]string = map[Level]string{
+	Easy:   "Easy",
+	Medium: "Medium",
+	Hard:   "Hard",
+}
+
+// ParseLevel - 依照名稱 (不分大小寫) 找出難度
+func ParseLevel(name string) (Level, error) {
+	for level, message := range LevelMessage {
+		if strings.EqualFold(message, name) {
+			return level, nil
+		}
+	}
+	return Easy, fmt.Errorf("game: unknown level %q", name)
+}
@@ -0,0 +1,32 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    Level
+		wantErr bool
+	}{
+		{name: "exact name", input: "Medium", want: Medium},
+		{name: "lower case", input: "hard", want: Hard},
+		{name: "unknown level", input: "expert", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, err := ParseLevel(tt.input)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, level)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package game
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// 盤面檔案的編碼字元，每列一行，空白行會被略過
+const (
+	LayoutMine = '*' // 地雷
+	LayoutSafe = '.' // 安全的格子
+)
+
+// ErrEmptyLayout - 盤面檔案沒有任何格子
+var ErrEmptyLayout = errors.New("game: empty board layout")
+
+// LoadGame - 從盤面檔案建立遊戲，'*' 為地雷、'.' 為安全的格子，每列長度必須相同
+func LoadGame(r io.Reader) (*Game, error) {
+	var rows []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		rows = append(rows, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 || len(rows[0]) == 0 {
+		return nil, ErrEmptyLayout
+	}
+	cols := len(rows[0])
+	var mines []Position
+	for row, line := range rows {
+		if len(line) != cols {
+			return nil, fmt.Errorf("game: row %d has %d cells, want %d", row+1, len(line), cols)
+		}
+		for col, ch := range line {
+			switch ch {
+			case LayoutMine:
+				mines = append(mines, Position{Row: row, Col: col})
+			case LayoutSafe:
+			default:
+				return nil, fmt.Errorf("game: row %d column %d: unexpected %q", row+1, col+1, ch)
+			}
+		}
+	}
+	if len(mines) == len(rows)*cols {
+		return nil, fmt.Errorf("game: board layout has no safe cell")
+	}
+	// 依照檔案的順序放置地雷
+	return NewGameWithPlacer(len(rows), cols, len(mines), NewListPlacer(mines)), nil
+}
@@ -0,0 +1,56 @@
+package game
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadGame(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		wantRows  []string
+		wantMines int
+		wantErr   bool
+	}{
+		{
+			name:      "layout with blank lines",
+			content:   "\n*..\n...\n\n..*\n",
+			wantRows:  []string{"*10", "121", "01*"},
+			wantMines: 2,
+		},
+		{
+			name:      "surrounding spaces are ignored",
+			content:   "  .*  \n  ..  \n",
+			wantRows:  []string{"1*", "11"},
+			wantMines: 1,
+		},
+		{name: "empty file", content: "\n\n", wantErr: true},
+		{name: "rows of different length", content: "..\n...\n", wantErr: true},
+		{name: "unexpected character", content: ".x\n..\n", wantErr: true},
+		{name: "no safe cell", content: "**\n**\n", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game, err := LoadGame(strings.NewReader(tt.content))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMines, game.MineCounts)
+			assert.Equal(t, tt.wantMines, game.Board.GetRemainingFlags())
+			assert.Len(t, game.Board.Mines(), tt.wantMines)
+			// 翻開所有格子比對周圍地雷數
+			for row := range game.Board.cells {
+				for col := range game.Board.cells[row] {
+					game.Board.cells[row][col].Revealed = true
+				}
+			}
+			assert.Equal(t, tt.wantRows, game.Board.VisibleRows())
+		})
+	}
+}
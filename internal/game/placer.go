@@ -0,0 +1,210 @@
+package game
+
+import (
+	"math"
+	"math/rand"
+)
+
+const (
+	clusterWeight = 4.0 // 群聚配置時每個相鄰地雷增加的權重
+	spreadFactor  = 8.0 // 分散配置時每個相鄰地雷讓權重縮小的倍數
+)
+
+// MinePlacer - 地雷配置策略，回傳 rows x cols 盤面上 mineCount 個地雷的位置
+//
+// 超出邊界或重複的位置會被 Board.PlaceMines 略過，回傳的位置超過 mineCount 時只取前 mineCount 個
+type MinePlacer interface {
+	Place(rows, cols, mineCount int) []Position
+}
+
+// MinePlacerFunc - 讓一般函式可以作為 MinePlacer
+type MinePlacerFunc func(rows, cols, mineCount int) []Position
+
+// Place - 呼叫 f
+func (f MinePlacerFunc) Place(rows, cols, mineCount int) []Position {
+	return f(rows, cols, mineCount)
+}
+
+// positionShuffler - 亂序器將所有格子洗牌後取前 mineCount 個作為地雷
+type positionShuffler func(coords []coord)
+
+// Place - 將所有格子依序排列後洗牌，取前 mineCount 個
+func (shuffle positionShuffler) Place(rows, cols, mineCount int) []Position {
+	coords := make([]coord, 0, rows*cols)
+	for row := 0; row < rows; row++ {
+		for col := 0; col < cols; col++ {
+			coords = append(coords, coord{Row: row, Col: col})
+		}
+	}
+	shuffle(coords)
+	positions := make([]Position, 0, min(mineCount, len(coords)))
+	for _, position := range coords[:min(mineCount, len(coords))] {
+		positions = append(positions, Position{Row: position.Row, Col: position.Col})
+	}
+	return positions
+}
+
+// NewUniformPlacer - 每個格子機率相同的隨機配置，每次呼叫都會得到不同的盤面
+func NewUniformPlacer() MinePlacer {
+	return defaultPositionShuffler
+}
+
+// NewSeededPlacer - 以固定 seed 的均勻隨機配置，相同 seed 與盤面大小會得到相同的地雷位置
+func NewSeededPlacer(seed int64) MinePlacer {
+	return newSeededPositionShuffler(seed)
+}
+
+// NewListPlacer - 依照 positions 的順序放置地雷，用於題目或讀取盤面檔案
+func NewListPlacer(positions []Position) MinePlacer {
+	return MinePlacerFunc(func(rows, cols, mineCount int) []Position {
+		return positions
+	})
+}
+
+// NewClusteredPlacer - 地雷傾向聚集成塊，已有越多相鄰地雷的格子越容易被選中
+func NewClusteredPlacer(seed int64) MinePlacer {
+	return weightedPlacer{seed: seed, weight: func(adjacent int) float64 {
+		return 1 + clusterWeight*float64(adjacent)
+	}}
+}
+
+// NewSpreadPlacer - 地雷傾向彼此分開，已有相鄰地雷的格子較不容易被選中
+func NewSpreadPlacer(seed int64) MinePlacer {
+	return weightedPlacer{seed: seed, weight: func(adjacent int) float64 {
+		return math.Pow(spreadFactor, -float64(adjacent))
+	}}
+}
+
+// NewSymmetricPlacer - 以盤面中心點對稱的配置，地雷數為奇數且盤面沒有中心格時，最後一個地雷無法對稱
+func NewSymmetricPlacer(seed int64) MinePlacer {
+	return MinePlacerFunc(func(rows, cols, mineCount int) []Position {
+		random := rand.New(rand.NewSource(seed))
+		cells := rows * cols
+		positions := make([]Position, 0, mineCount)
+		at := func(index int) Position {
+			return Position{Row: index / cols, Col: index % cols}
+		}
+		// 奇數個地雷時優先放在中心格
+		if center := cells / 2; cells%2 == 1 && mineCount%2 == 1 {
+			positions = append(positions, at(center))
+		}
+		// 每一組為互相對稱的兩格，依 seed 洗牌後成對放置
+		pairs := make([]int, cells/2)
+		for index := range pairs {
+			pairs[index] = index
+		}
+		random.Shuffle(len(pairs), func(i, j int) {
+			pairs[i], pairs[j] = pairs[j], pairs[i]
+		})
+		for _, index := range pairs {
+			if len(positions) >= mineCount {
+				break
+			}
+			positions = append(positions, at(index))
+			if len(positions) < mineCount {
+				positions = append(positions, at(cells-1-index))
+			}
+		}
+		return positions
+	})
+}
+
+// weightedPlacer - 逐一抽出地雷，每個格子被抽中的權重由已放置的相鄰地雷數決定
+type weightedPlacer struct {
+	seed   int64
+	weight func(adjacent int) float64 // 必須大於 0
+}
+
+// Place - 以 Fenwick tree 維護權重總和，每放一個地雷只需更新周圍 8 格
+func (p weightedPlacer) Place(rows, cols, mineCount int) []Position {
+	random := rand.New(rand.NewSource(p.seed))
+	cells := rows * cols
+	mineCount = min(mineCount, cells)
+	adjacent := make([]int, cells)
+	isMine := make([]bool, cells)
+	weights := newFenwickTree(cells)
+	for index := range cells {
+		weights.add(index, p.weight(0))
+	}
+	positions := make([]Position, 0, mineCount)
+	for len(positions) < mineCount {
+		index := weights.find(random.Float64() * weights.total())
+		row, col := index/cols, index%cols
+		isMine[index] = true
+		weights.add(index, -weights.value(index))
+		positions = append(positions, Position{Row: row, Col: col})
+		for _, direction := range neighborDirections {
+			neighborRow, neighborCol := row+direction.Row, col+direction.Col
+			if neighborRow < 0 || neighborRow >= rows || neighborCol < 0 || neighborCol >= cols {
+				continue
+			}
+			neighbor := neighborRow*cols + neighborCol
+			adjacent[neighbor]++
+			if !isMine[neighbor] {
+				weights.add(neighbor, p.weight(adjacent[neighbor])-weights.value(neighbor))
+			}
+		}
+	}
+	return positions
+}
+
+// fenwickTree - 支援單點更新與依前綴和搜尋的權重表
+type fenwickTree struct {
+	tree   []float64
+	values []float64
+}
+
+// newFenwickTree - 建立 size 個權重皆為 0 的表
+func newFenwickTree(size int) *fenwickTree {
+	return &fenwickTree{tree: make([]float64, size+1), values: make([]float64, size)}
+}
+
+// add - 第 index 個權重加上 delta
+func (f *fenwickTree) add(index int, delta float64) {
+	f.values[index] += delta
+	for i := index + 1; i < len(f.tree); i += i & -i {
+		f.tree[i] += delta
+	}
+}
+
+// value - 第 index 個權重
+func (f *fenwickTree) value(index int) float64 {
+	return f.values[index]
+}
+
+// total - 所有權重的總和
+func (f *fenwickTree) total() float64 {
+	sum := 0.0
+	for i := len(f.values); i > 0; i -= i & -i {
+		sum += f.tree[i]
+	}
+	return sum
+}
+
+// find - 前綴和超過 target 的第一個權重大於 0 的位置
+func (f *fenwickTree) find(target float64) int {
+	index := 0
+	for step := 1 << bitLength(len(f.values)); step > 0; step >>= 1 {
+		if next := index + step; next < len(f.tree) && f.tree[next] <= target {
+			index = next
+			target -= f.tree[next]
+		}
+	}
+	// 浮點誤差可能落在權重為 0 的格子上，往後找到第一個可以選的格子
+	for index < len(f.values)-1 && f.values[index] <= 0 {
+		index++
+	}
+	for index > 0 && f.values[index] <= 0 {
+		index--
+	}
+	return index
+}
+
+// bitLength - n 的二進位位數
+func bitLength(n int) int {
+	length := 0
+	for ; n > 0; n >>= 1 {
+		length++
+	}
+	return length
+}
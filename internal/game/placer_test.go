@@ -0,0 +1,115 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// minePositions - 盤面上所有地雷的位置
+func minePositions(board *Board) map[Position]bool {
+	positions := map[Position]bool{}
+	for row := range board.cells {
+		for col, cell := range board.cells[row] {
+			if cell.IsMine {
+				positions[Position{Row: row, Col: col}] = true
+			}
+		}
+	}
+	return positions
+}
+
+// averageAdjacentMines - 每個地雷周圍平均的地雷數，用來比較群聚程度
+func averageAdjacentMines(board *Board) float64 {
+	total := 0
+	positions := minePositions(board)
+	for position := range positions {
+		for _, direction := range neighborDirections {
+			if positions[Position{Row: position.Row + direction.Row, Col: position.Col + direction.Col}] {
+				total++
+			}
+		}
+	}
+	return float64(total) / float64(len(positions))
+}
+
+func TestPlacers(t *testing.T) {
+	tests := []struct {
+		name          string
+		placer        func() MinePlacer
+		deterministic bool
+	}{
+		{name: "uniform", placer: NewUniformPlacer},
+		{name: "seeded", placer: func() MinePlacer { return NewSeededPlacer(7) }, deterministic: true},
+		{name: "clustered", placer: func() MinePlacer { return NewClusteredPlacer(7) }, deterministic: true},
+		{name: "spread", placer: func() MinePlacer { return NewSpreadPlacer(7) }, deterministic: true},
+		{name: "symmetric", placer: func() MinePlacer { return NewSymmetricPlacer(7) }, deterministic: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewGameWithPlacer(16, 30, 99, tt.placer())
+			assert.Equal(t, 99, game.MineCounts)
+			assert.Len(t, minePositions(game.Board), 99)
+			assert.Equal(t, 99, game.Board.GetRemainingFlags())
+			if tt.deterministic {
+				assert.Equal(t, minePositions(game.Board), minePositions(NewGameWithPlacer(16, 30, 99, tt.placer()).Board))
+			}
+		})
+	}
+}
+
+func TestSeededPlacerMatchesSeededGame(t *testing.T) {
+	assert.Equal(t, NewGameWithSeed(9, 9, 10, 42).Board.cells, NewGameWithPlacer(9, 9, 10, NewSeededPlacer(42)).Board.cells)
+}
+
+func TestClusteredAndSpreadPlacers(t *testing.T) {
+	uniform := averageAdjacentMines(NewGameWithPlacer(16, 30, 99, NewSeededPlacer(3)).Board)
+	clustered := averageAdjacentMines(NewGameWithPlacer(16, 30, 99, NewClusteredPlacer(3)).Board)
+	spread := averageAdjacentMines(NewGameWithPlacer(16, 30, 99, NewSpreadPlacer(3)).Board)
+	assert.Greater(t, clustered, uniform)
+	assert.Less(t, spread, uniform)
+}
+
+func TestSymmetricPlacer(t *testing.T) {
+	tests := []struct {
+		name       string
+		rows, cols int
+		mines      int
+		wantCenter bool
+	}{
+		{name: "odd board with an odd mine count uses the center", rows: 5, cols: 5, mines: 5, wantCenter: true},
+		{name: "odd board with an even mine count", rows: 5, cols: 5, mines: 6},
+		{name: "even board", rows: 4, cols: 6, mines: 8},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewGameWithPlacer(tt.rows, tt.cols, tt.mines, NewSymmetricPlacer(1))
+			positions := minePositions(game.Board)
+			assert.Len(t, positions, tt.mines)
+			for position := range positions {
+				assert.True(t, positions[Position{Row: tt.rows - 1 - position.Row, Col: tt.cols - 1 - position.Col}])
+			}
+			assert.Equal(t, tt.wantCenter, positions[Position{Row: tt.rows / 2, Col: tt.cols / 2}])
+		})
+	}
+}
+
+func TestListPlacer(t *testing.T) {
+	game := NewGameWithPlacer(3, 3, 3, NewListPlacer([]Position{
+		{Row: 0, Col: 0},
+		{Row: 0, Col: 0},
+		{Row: 5, Col: 1},
+		{Row: 2, Col: 2},
+	}))
+	assert.Equal(t, map[Position]bool{{Row: 0, Col: 0}: true, {Row: 2, Col: 2}: true}, minePositions(game.Board))
+	assert.Equal(t, 2, game.MineCounts)
+	assert.Equal(t, 2, game.Board.GetRemainingFlags())
+	game.RevealCell(1, 1)
+	game.RevealCell(0, 1)
+	game.RevealCell(0, 2)
+	game.RevealCell(1, 0)
+	game.RevealCell(1, 2)
+	game.RevealCell(2, 0)
+	game.RevealCell(2, 1)
+	assert.True(t, game.IsPlayerWin)
+}
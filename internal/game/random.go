@@ -1,16 +1,39 @@
 package game
 
 import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
 	"math/rand"
-	"time"
 )
 
+// randomSeed - 以 crypto/rand 產生不為 0 的正數 seed，無法從開始時間推算
+func randomSeed() int64 {
+	var buf [8]byte
+	for {
+		cryptorand.Read(buf[:])
+		if seed := int64(binary.BigEndian.Uint64(buf[:]) >> 1); seed != 0 {
+			return seed
+		}
+	}
+}
+
+// defaultPositionShuffler - 以 randomSeed 建立的亂數來源洗牌，每次呼叫都不同
 var defaultPositionShuffler positionShuffler = func(coords []coord) {
 	if len(coords) <= 1 {
 		return
 	}
-	rand.New(rand.NewSource(time.Now().UnixNano()))
-	rand.Shuffle(len(coords), func(i, j int) {
+	random := rand.New(rand.NewSource(randomSeed()))
+	random.Shuffle(len(coords), func(i, j int) {
 		coords[i], coords[j] = coords[j], coords[i]
 	})
 }
+
+// newSeededPositionShuffler - 以固定 seed 洗牌，相同 seed 會得到相同的地雷配置
+func newSeededPositionShuffler(seed int64) positionShuffler {
+	return func(coords []coord) {
+		random := rand.New(rand.NewSource(seed))
+		random.Shuffle(len(coords), func(i, j int) {
+			coords[i], coords[j] = coords[j], coords[i]
+		})
+	}
+}
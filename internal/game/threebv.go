@@ -0,0 +1,57 @@
+package game
+
+// neighborDirections - 鄰近所有方向
+var neighborDirections = [8]coord{
+	{Row: -1, Col: -1}, {Row: -1, Col: 0}, {Row: -1, Col: 1},
+	{Row: 0, Col: -1}, {Row: 0, Col: 1},
+	{Row: 1, Col: -1}, {Row: 1, Col: 0}, {Row: 1, Col: 1},
+}
+
+// ThreeBV - 計算盤面的 3BV (Bechtel's Board Benchmark Value)
+//
+// 3BV 為不插旗解完盤面所需的最少點擊數：每一塊相連的空白區域 (opening) 算一次，
+// 加上所有不與空白格相鄰的數字格
+func (b *Board) ThreeBV() int {
+	visited := make([][]bool, b.Rows)
+	for row := range visited {
+		visited[row] = make([]bool, b.Cols)
+	}
+	count := 0
+	// 先計算 opening，並將 opening 連帶翻開的格子標記為已拜訪
+	for row := range b.cells {
+		for col, cell := range b.cells[row] {
+			if visited[row][col] || cell.IsMine || cell.AdjacenetMines != 0 {
+				continue
+			}
+			count++
+			visitQueue := []coord{{Row: row, Col: col}}
+			visited[row][col] = true
+			for len(visitQueue) > 0 {
+				current := visitQueue[0]
+				visitQueue = visitQueue[1:]
+				if b.cells[current.Row][current.Col].AdjacenetMines != 0 {
+					continue
+				}
+				for _, direction := range neighborDirections {
+					neighborRow, neighborCol := current.Row+direction.Row, current.Col+direction.Col
+					if neighborRow < 0 || neighborRow >= b.Rows ||
+						neighborCol < 0 || neighborCol >= b.Cols ||
+						visited[neighborRow][neighborCol] {
+						continue
+					}
+					visited[neighborRow][neighborCol] = true
+					visitQueue = append(visitQueue, coord{Row: neighborRow, Col: neighborCol})
+				}
+			}
+		}
+	}
+	// 剩下沒被 opening 涵蓋的數字格各需要一次點擊
+	for row := range b.cells {
+		for col, cell := range b.cells[row] {
+			if !visited[row][col] && !cell.IsMine {
+				count++
+			}
+		}
+	}
+	return count
+}
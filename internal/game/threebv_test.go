@@ -0,0 +1,64 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThreeBV(t *testing.T) {
+	tests := []struct {
+		name  string
+		mines [][2]int
+		rows  int
+		cols  int
+		want  int
+	}{
+		{
+			name: "board without mines is a single opening",
+			rows: 3,
+			cols: 3,
+			want: 1,
+		},
+		{
+			name:  "single mine in the corner leaves one opening",
+			rows:  3,
+			cols:  3,
+			mines: [][2]int{{0, 0}},
+			want:  1,
+		},
+		{
+			name:  "mine in the center isolates every number",
+			rows:  3,
+			cols:  3,
+			mines: [][2]int{{1, 1}},
+			want:  8,
+		},
+		{
+			name:  "wall of mines splits two openings",
+			rows:  3,
+			cols:  5,
+			mines: [][2]int{{0, 2}, {1, 2}, {2, 2}},
+			want:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			board := NewBoard(tt.rows, tt.cols, len(tt.mines))
+			for _, mine := range tt.mines {
+				board.cells[mine[0]][mine[1]].IsMine = true
+			}
+			board.CalculateAdjacentMines()
+			assert.Equal(t, tt.want, board.ThreeBV())
+		})
+	}
+}
+
+func TestNewGameWithSeed(t *testing.T) {
+	first := NewGameWithSeed(9, 9, 10, 42)
+	second := NewGameWithSeed(9, 9, 10, 42)
+	other := NewGameWithSeed(9, 9, 10, 43)
+	assert.Equal(t, first.Board.cells, second.Board.cells)
+	assert.NotEqual(t, first.Board.cells, other.Board.cells)
+	assert.Equal(t, int64(42), first.Seed)
+}
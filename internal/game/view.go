@@ -0,0 +1,76 @@
+package game
+
+// 玩家可見盤面的編碼字元，每一格以一個字元表示
+const (
+	ViewHidden       = '#' // 尚未翻開
+	ViewFlag         = 'F' // 尚未翻開但已插旗
+	ViewMine         = '*' // 已翻開的地雷
+	ViewFlaggedMine  = '+' // 遊戲結束時被正確插旗的地雷
+	ViewRevealedZero = '0' // 已翻開的格子，'0' ~ '8' 代表周圍地雷數
+)
+
+// VisibleRows - 將玩家可見的盤面編碼成每列一個字串，不會洩漏未翻開格子的地雷資訊
+func (b *Board) VisibleRows() []string {
+	rows := make([]string, b.Rows)
+	for row := range b.cells {
+		line := make([]byte, b.Cols)
+		for col, cell := range b.cells[row] {
+			line[col] = visibleChar(cell)
+		}
+		rows[row] = string(line)
+	}
+	return rows
+}
+
+// visibleChar - 單一格子的可見編碼
+func visibleChar(cell *Cell) byte {
+	switch {
+	case !cell.Revealed && cell.Flagged:
+		return ViewFlag
+	case !cell.Revealed:
+		return ViewHidden
+	case cell.IsMine && cell.Flagged:
+		return ViewFlaggedMine
+	case cell.IsMine:
+		return ViewMine
+	default:
+		return byte(ViewRevealedZero + cell.AdjacenetMines)
+	}
+}
+
+// NewBoardFromVisibleRows - 由可見盤面字串還原出只供顯示用的 Board
+//
+// 還原出的 Board 只包含玩家看得到的資訊，未翻開的格子一律視為非地雷
+func NewBoardFromVisibleRows(rows []string, remainingFlags int) *Board {
+	cols := 0
+	if len(rows) > 0 {
+		cols = len(rows[0])
+	}
+	board := &Board{
+		Rows:           len(rows),
+		Cols:           cols,
+		remainingFlags: remainingFlags,
+	}
+	board.cells = make([][]*Cell, len(rows))
+	for row := range board.cells {
+		board.cells[row] = make([]*Cell, cols)
+		for col := range board.cells[row] {
+			cell := &Cell{}
+			if col < len(rows[row]) {
+				switch ch := rows[row][col]; {
+				case ch == ViewFlag:
+					cell.Flagged = true
+				case ch == ViewMine:
+					cell.Revealed, cell.IsMine = true, true
+				case ch == ViewFlaggedMine:
+					cell.Revealed, cell.IsMine, cell.Flagged = true, true, true
+				case ch >= '0' && ch <= '8':
+					cell.Revealed = true
+					cell.AdjacenetMines = int(ch - ViewRevealedZero)
+				}
+			}
+			board.cells[row][col] = cell
+		}
+	}
+	return board
+}
@@ -0,0 +1,82 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVisibleRows(t *testing.T) {
+	tests := []struct {
+		name   string
+		reveal [][2]int
+		flag   [][2]int
+		want   []string
+	}{
+		{
+			name: "untouched board hides every cell",
+			want: []string{"###", "###", "###"},
+		},
+		{
+			name: "flag on hidden cell",
+			flag: [][2]int{{0, 0}},
+			want: []string{"F##", "###", "###"},
+		},
+		{
+			name:   "reveal number keeps mines hidden",
+			reveal: [][2]int{{1, 1}},
+			want:   []string{"###", "#1#", "###"},
+		},
+		{
+			name:   "reveal mine shows mines",
+			reveal: [][2]int{{0, 0}},
+			want:   []string{"*##", "###", "###"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			game := NewGame(3, 3, 1)
+			game.Init(&Board{
+				Rows: 3,
+				Cols: 3,
+				cells: [][]*Cell{
+					{{IsMine: true}, {AdjacenetMines: 1}, {}},
+					{{AdjacenetMines: 1}, {AdjacenetMines: 1}, {}},
+					{{}, {}, {}},
+				},
+			}, positionShuffler(func(coords []coord) {}))
+			game.Board.mineCoords = []coord{{Row: 0, Col: 0}}
+			for _, position := range tt.flag {
+				game.Board.ToggleFlag(position[0], position[1])
+			}
+			for _, position := range tt.reveal {
+				game.Board.Reveal(position[0], position[1])
+			}
+			assert.Equal(t, tt.want, game.Board.VisibleRows())
+		})
+	}
+}
+
+func TestNewBoardFromVisibleRows(t *testing.T) {
+	rows := []string{"F#*", "+12", "000"}
+	board := NewBoardFromVisibleRows(rows, 3)
+	assert.Equal(t, 3, board.Rows)
+	assert.Equal(t, 3, board.Cols)
+	assert.Equal(t, 3, board.GetRemainingFlags())
+	assert.Equal(t, &Cell{Flagged: true}, board.GetCell(0, 0))
+	assert.Equal(t, &Cell{}, board.GetCell(0, 1))
+	assert.Equal(t, &Cell{Revealed: true, IsMine: true}, board.GetCell(0, 2))
+	assert.Equal(t, &Cell{Revealed: true, IsMine: true, Flagged: true}, board.GetCell(1, 0))
+	assert.Equal(t, &Cell{Revealed: true, AdjacenetMines: 2}, board.GetCell(1, 2))
+	assert.Equal(t, rows, board.VisibleRows())
+}
+
+func TestRevealCellStopsTimerOnFinish(t *testing.T) {
+	game := NewGame(2, 2, 0)
+	game.RevealCell(0, 0)
+	assert.True(t, game.IsPlayerWin)
+	assert.False(t, game.endTime.IsZero())
+	// 結束後的動作不再影響盤面
+	game.ToggleFlag(1, 1)
+	assert.False(t, game.Board.GetCell(1, 1).Flagged)
+}
@@ -0,0 +1,237 @@
+package layout
+
+import (
+	"image/color"
+	"math"
+	"math/rand/v2"
+	"sort"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+)
+
+const (
+	rippleFramesPerStep    = 2   // flood fill 每一層 BFS 相隔的 frame 數
+	revealPopFrames        = 8   // 格子翻開時覆蓋色塊縮小消失的 frame 數
+	explosionFramesPerMine = 4   // 連鎖爆炸每顆地雷相隔的 frame 數
+	maxExplosionFrames     = 90  // 連鎖爆炸最長的 frame 數，地雷多時縮短間隔
+	explosionFlashFrames   = 16  // 每顆地雷爆炸閃光的 frame 數
+	confettiFrames         = 150 // 獲勝彩帶持續的 frame 數
+	confettiCount          = 80  // 獲勝彩帶數量
+	confettiGravity        = 0.12
+)
+
+// confetti - 獲勝時從盤面上方落下的彩帶
+type confetti struct {
+	x, y   float64
+	vx, vy float64
+	angle  float64
+	spin   float64
+	color  color.RGBA
+}
+
+// animator - 翻開、爆炸與獲勝動畫的狀態，tick 每個 Update 加一
+type animator struct {
+	tick      int
+	endTick   int                   // 所有動畫結束的 tick
+	revealAt  map[game.Position]int // 格子開始顯示的 tick，之前畫成未翻開
+	explodeAt map[game.Position]int // 地雷爆炸的 tick，之前畫成未翻開
+	confetti  []confetti
+	skipped   bool // 本 frame 的輸入跳過了動畫
+}
+
+// active - 是否還有動畫在播放
+func (a *animator) active() bool {
+	return a.tick <= a.endTick && (len(a.revealAt) > 0 || len(a.explodeAt) > 0 || len(a.confetti) > 0)
+}
+
+// reset - 清掉所有動畫
+func (a *animator) reset() {
+	a.revealAt = nil
+	a.explodeAt = nil
+	a.confetti = nil
+	a.endTick = a.tick
+}
+
+// extend - 延長動畫結束的 tick
+func (a *animator) extend(tick int) {
+	a.endTick = max(a.endTick, tick)
+}
+
+// SetAnimations - 開關動畫並寫回設定檔，關閉時立刻結束播放中的動畫
+func (g *GameLayout) SetAnimations(enabled bool) {
+	g.settings.Animations = enabled
+	if !enabled {
+		g.animation.reset()
+	}
+	g.saveSettings()
+}
+
+// SkipAnimations - 立刻結束播放中的動畫
+func (g *GameLayout) SkipAnimations() {
+	g.animation.reset()
+}
+
+// animationListener - 依照遊戲事件排程動畫
+func (g *GameLayout) animationListener() game.EventListener {
+	return func(event game.Event) {
+		if !g.settings.Animations {
+			return
+		}
+		switch event.Type {
+		case game.EventReveal:
+			g.scheduleRipple(event.Steps)
+		case game.EventExplode:
+			g.scheduleExplosion(event.Row, event.Col)
+		case game.EventWin:
+			g.scheduleConfetti()
+		}
+	}
+}
+
+// scheduleRipple - flood fill 的格子依照 BFS 距離由內往外翻開
+func (g *GameLayout) scheduleRipple(steps []game.RevealStep) {
+	if len(steps) <= 1 {
+		return
+	}
+	if g.animation.revealAt == nil {
+		g.animation.revealAt = map[game.Position]int{}
+	}
+	for _, step := range steps {
+		at := g.animation.tick + step.Depth*rippleFramesPerStep
+		g.animation.revealAt[step.Position] = at
+		g.animation.extend(at + revealPopFrames)
+	}
+}
+
+// scheduleExplosion - 從踩到的地雷開始，由近到遠連鎖引爆其他沒有插旗的地雷
+func (g *GameLayout) scheduleExplosion(row, col int) {
+	board := g.gameInstance.Board
+	var mines []game.Position
+	for _, mine := range board.Mines() {
+		if (mine.Row != row || mine.Col != col) && !board.GetCell(mine.Row, mine.Col).Flagged {
+			mines = append(mines, mine)
+		}
+	}
+	distance := func(mine game.Position) int {
+		return (mine.Row-row)*(mine.Row-row) + (mine.Col-col)*(mine.Col-col)
+	}
+	sort.SliceStable(mines, func(i, j int) bool {
+		return distance(mines[i]) < distance(mines[j])
+	})
+	step := float64(explosionFramesPerMine)
+	if len(mines) > 0 {
+		step = min(step, float64(maxExplosionFrames)/float64(len(mines)))
+	}
+	g.animation.explodeAt = map[game.Position]int{{Row: row, Col: col}: g.animation.tick}
+	for index, mine := range mines {
+		g.animation.explodeAt[mine] = g.animation.tick + int(float64(index+1)*step)
+	}
+	g.animation.extend(g.animation.tick + int(float64(len(mines))*step) + explosionFlashFrames)
+}
+
+// scheduleConfetti - 從盤面上方撒下彩帶
+func (g *GameLayout) scheduleConfetti() {
+	viewWidth, _ := g.viewportSize()
+	g.animation.confetti = make([]confetti, confettiCount)
+	for index := range g.animation.confetti {
+		g.animation.confetti[index] = confetti{
+			x:     rand.Float64() * viewWidth,
+			y:     PanelHeight - rand.Float64()*gridSize*2,
+			vx:    rand.Float64()*2 - 1,
+			vy:    rand.Float64() * 2,
+			angle: rand.Float64() * math.Pi,
+			spin:  rand.Float64()*0.3 - 0.15,
+			color: g.numberColor(index%8 + 1),
+		}
+	}
+	g.animation.extend(g.animation.tick + confettiFrames)
+}
+
+// updateAnimations - 推進動畫，播放中任何按鍵、點擊或觸控都會跳過動畫
+func (g *GameLayout) updateAnimations() {
+	g.animation.skipped = false
+	if !g.animation.active() {
+		g.animation.reset()
+		g.animation.tick++
+		return
+	}
+	if len(inpututil.AppendJustPressedKeys(nil)) > 0 ||
+		inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) ||
+		inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) ||
+		len(inpututil.AppendJustPressedTouchIDs(nil)) > 0 {
+		g.SkipAnimations()
+		g.animation.skipped = true
+		return
+	}
+	g.animation.tick++
+	for index := range g.animation.confetti {
+		piece := &g.animation.confetti[index]
+		piece.vy += confettiGravity
+		piece.x += piece.vx
+		piece.y += piece.vy
+		piece.angle += piece.spin
+	}
+}
+
+// animationHides - 格子是否還在等待翻開或爆炸，需要先畫成未翻開
+func (g *GameLayout) animationHides(row, col int) bool {
+	position := game.Position{Row: row, Col: col}
+	if at, ok := g.animation.revealAt[position]; ok && g.animation.tick < at {
+		return true
+	}
+	if at, ok := g.animation.explodeAt[position]; ok && g.animation.tick < at {
+		return true
+	}
+	return false
+}
+
+// drawCellAnimations - 在盤面圖上畫出翻開時縮小消失的色塊與爆炸閃光
+func (g *GameLayout) drawCellAnimations(screen *ebiten.Image) {
+	if !g.animation.active() {
+		return
+	}
+	minRow, minCol, maxRow, maxCol := g.visibleCells()
+	visible := func(position game.Position) bool {
+		return position.Row >= minRow && position.Row < maxRow && position.Col >= minCol && position.Col < maxCol
+	}
+	for position, at := range g.animation.revealAt {
+		elapsed := g.animation.tick - at
+		if elapsed < 0 || elapsed >= revealPopFrames || !visible(position) {
+			continue
+		}
+		x, y := g.cellOrigin(position.Row, position.Col)
+		size := float32(gridSize-1) * (1 - float32(elapsed)/revealPopFrames)
+		offset := (float32(gridSize-1) - size) / 2
+		vector.DrawFilledRect(screen, float32(x)+offset, float32(y)+offset, size, size, g.theme.CoveredCell, false)
+	}
+	for position, at := range g.animation.explodeAt {
+		elapsed := g.animation.tick - at
+		if elapsed < 0 || elapsed >= explosionFlashFrames || !visible(position) {
+			continue
+		}
+		x, y := g.cellOrigin(position.Row, position.Col)
+		progress := float32(elapsed) / explosionFlashFrames
+		flash := withAlpha(g.theme.ExplodedMine, uint8(0xd0*(1-progress)))
+		vector.DrawFilledCircle(screen, float32(x)+gridSize/2, float32(y)+gridSize/2,
+			gridSize*(0.3+progress), flash, true)
+	}
+}
+
+// drawConfetti - 在盤面上畫出獲勝彩帶
+func (g *GameLayout) drawConfetti(screen *ebiten.Image) {
+	if !g.animation.active() || len(g.animation.confetti) == 0 {
+		return
+	}
+	viewWidth, viewHeight := g.viewportSize()
+	for _, piece := range g.animation.confetti {
+		if piece.y < PanelHeight || piece.y > PanelHeight+viewHeight || piece.x < 0 || piece.x > viewWidth {
+			continue
+		}
+		// 以旋轉角度改變寬度，看起來像在翻轉
+		width := float32(6 * math.Abs(math.Cos(piece.angle)))
+		vector.DrawFilledRect(screen, float32(piece.x)-width/2, float32(piece.y)-2, width+1, 4, piece.color, false)
+	}
+}
@@ -0,0 +1,59 @@
+package layout
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/a11y"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+)
+
+// SetAnnouncer - 以文字唸出游標所在格子、動作結果與勝負，nil 代表關閉
+func (g *GameLayout) SetAnnouncer(announcer a11y.Announcer) {
+	g.announcer = announcer
+	g.announcements = nil
+}
+
+// announce - 累積本 frame 要唸出的訊息
+func (g *GameLayout) announce(message string) {
+	if g.announcer == nil || message == "" {
+		return
+	}
+	g.announcements = append(g.announcements, message)
+}
+
+// flushAnnouncements - 一個 frame 的訊息合併成一則，避免 live region 只唸出最後一則
+func (g *GameLayout) flushAnnouncements() {
+	if g.announcer == nil || len(g.announcements) == 0 {
+		return
+	}
+	g.announcer.Announce(strings.Join(g.announcements, ". "))
+	g.announcements = g.announcements[:0]
+}
+
+// announceListener - 把遊戲事件轉成文字
+func (g *GameLayout) announceListener() game.EventListener {
+	return func(event game.Event) {
+		if g.announcer == nil {
+			return
+		}
+		message := a11y.DescribeEvent(g.gameInstance.Board, event)
+		if event.Type == game.EventWin {
+			message = fmt.Sprintf("%s in %d seconds", message, g.gameInstance.GetElapsedTime())
+		}
+		g.announce(message)
+	}
+}
+
+// announceCursor - 唸出游標所在的格子
+func (g *GameLayout) announceCursor() {
+	if g.announcer == nil {
+		return
+	}
+	g.announce(a11y.DescribeCell(g.gameInstance.Board, g.cursor.Row, g.cursor.Col))
+}
+
+// announceNewGame - 唸出新盤面的大小與地雷數
+func (g *GameLayout) announceNewGame() {
+	g.announce(fmt.Sprintf("new game, %d rows by %d columns, %d mines", g.Rows, g.Cols, g.MineCounts))
+}
@@ -0,0 +1,148 @@
+package layout
+
+import (
+	"bytes"
+	"io"
+	"log"
+	"slices"
+
+	"github.com/hajimehoshi/ebiten/v2/audio"
+	"github.com/hajimehoshi/ebiten/v2/audio/wav"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/sound"
+)
+
+const volumeStep = 0.1 // 設定畫面每次調整的音量
+
+// audioContext - ebiten 整個程式只能建立一個 audio.Context
+var audioContext *audio.Context
+
+// revealSounds - 翻開類的音效依優先順序排列，同一個 frame 只播放其中優先的一個
+//
+// 例如 chord 會連帶翻開多個格子，只播 chord 音效而不是每格各播一次翻開音效
+var revealSounds = []string{sound.Chord, sound.Cascade, sound.Reveal}
+
+// soundPlayer - 解碼好的音效、本 frame 要播放的音效與正在播放的 player
+type soundPlayer struct {
+	sounds  map[string][]byte
+	queued  []string
+	playing []*audio.Player
+}
+
+// newSoundPlayer - 解碼所有內建音效，失敗的音效會被略過
+func newSoundPlayer() *soundPlayer {
+	if audioContext == nil {
+		audioContext = audio.NewContext(sound.SampleRate)
+	}
+	player := &soundPlayer{sounds: map[string][]byte{}}
+	for _, name := range sound.Names() {
+		data, err := sound.WAV(name)
+		if err != nil {
+			log.Printf("sound: %v", err)
+			continue
+		}
+		stream, err := wav.DecodeWithSampleRate(sound.SampleRate, bytes.NewReader(data))
+		if err != nil {
+			log.Printf("sound: %s: %v", name, err)
+			continue
+		}
+		pcm, err := io.ReadAll(stream)
+		if err != nil {
+			log.Printf("sound: %s: %v", name, err)
+			continue
+		}
+		player.sounds[name] = pcm
+	}
+	return player
+}
+
+// play - 以 volume 播放音效，同一個音效可以重疊播放
+func (p *soundPlayer) play(name string, volume float64) {
+	pcm, ok := p.sounds[name]
+	if !ok {
+		return
+	}
+	// 清掉已經播完的 player
+	playing := p.playing[:0]
+	for _, player := range p.playing {
+		if player.IsPlaying() {
+			playing = append(playing, player)
+		} else {
+			player.Close()
+		}
+	}
+	player := audioContext.NewPlayerFromBytes(pcm)
+	player.SetVolume(volume)
+	player.Play()
+	p.playing = append(playing, player)
+}
+
+// playSound - 累積本 frame 要播放的音效，靜音時不播放
+func (g *GameLayout) playSound(name string) {
+	if g.sound == nil || g.settings.Muted || g.settings.Volume <= 0 {
+		return
+	}
+	g.sound.queued = append(g.sound.queued, name)
+}
+
+// flushSounds - 依照設定的音量播放本 frame 累積的音效，同一個音效只播一次，翻開類的音效只播最優先的一個
+func (g *GameLayout) flushSounds() {
+	if g.sound == nil || len(g.sound.queued) == 0 {
+		return
+	}
+	queued := g.sound.queued
+	g.sound.queued = g.sound.queued[:0]
+	revealSound := ""
+	for _, name := range revealSounds {
+		if slices.Contains(queued, name) {
+			revealSound = name
+			break
+		}
+	}
+	played := map[string]bool{}
+	for _, name := range queued {
+		if played[name] || slices.Contains(revealSounds, name) && name != revealSound {
+			continue
+		}
+		played[name] = true
+		g.sound.play(name, g.settings.Volume)
+	}
+}
+
+// soundListener - 依照遊戲事件播放對應的音效
+func (g *GameLayout) soundListener() game.EventListener {
+	return func(event game.Event) {
+		switch event.Type {
+		case game.EventReveal:
+			// 踩到地雷只播爆炸聲
+			if g.gameInstance.Board.GetCell(event.Row, event.Col).IsMine {
+				return
+			}
+			if event.Cells > 1 {
+				g.playSound(sound.Cascade)
+			} else {
+				g.playSound(sound.Reveal)
+			}
+		case game.EventFlag, game.EventUnflag:
+			g.playSound(sound.Flag)
+		case game.EventChord:
+			g.playSound(sound.Chord)
+		case game.EventExplode:
+			g.playSound(sound.Explosion)
+		case game.EventWin:
+			g.playSound(sound.Victory)
+		}
+	}
+}
+
+// SetVolume - 調整音量 (0~1) 並寫回設定檔
+func (g *GameLayout) SetVolume(volume float64) {
+	g.settings.Volume = min(max(volume, 0), 1)
+	g.saveSettings()
+}
+
+// ToggleMute - 切換靜音並寫回設定檔
+func (g *GameLayout) ToggleMute() {
+	g.settings.Muted = !g.settings.Muted
+	g.saveSettings()
+}
@@ -0,0 +1,289 @@
+package layout
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	maxViewportRows = 30   // 盤面超過這個大小時改用可捲動的視窗 (Hard 剛好放得下)
+	maxViewportCols = 30   // 同上
+	minCameraZoom   = 0.25 // 最多縮小的比例
+	maxCameraZoom   = 2.0  // 最多放大的比例
+	wheelZoomStep   = 1.1  // 滾輪每一格的縮放比例
+	minimapSize     = 120  // 小地圖較長一邊的大小
+	minimapMargin   = 8    // 小地圖與畫面邊緣的距離
+)
+
+// camera - 大盤面的可視範圍，X、Y 為畫面左上角在盤面上的 pixel 位置 (以 gridSize 計算)
+type camera struct {
+	X        float64
+	Y        float64
+	Zoom     float64
+	dragFrom image.Point // 滑鼠中鍵拖曳的上一個位置
+	dragged  bool        // 這次按下中鍵是否已經拖曳過，放開時不再 chord
+}
+
+// hasCamera - 盤面是否大到需要捲動
+func (g *GameLayout) hasCamera() bool {
+	return g.Rows > maxViewportRows || g.Cols > maxViewportCols
+}
+
+// viewportSize - 面板下方顯示盤面的範圍大小
+func (g *GameLayout) viewportSize() (float64, float64) {
+	return float64(gridSize * min(g.Cols, maxViewportCols)), float64(gridSize * min(g.Rows, maxViewportRows))
+}
+
+// resetCamera - 新遊戲時回到左上角並恢復原始大小
+func (g *GameLayout) resetCamera() {
+	g.camera = camera{Zoom: 1}
+}
+
+// minZoom - 可以縮小到整個盤面剛好放進畫面，但不小於 minCameraZoom
+func (g *GameLayout) minZoom() float64 {
+	viewWidth, viewHeight := g.viewportSize()
+	fit := min(viewWidth/float64(gridSize*g.Cols), viewHeight/float64(gridSize*g.Rows))
+	return max(minCameraZoom, min(fit, 1))
+}
+
+// clampCamera - 限制縮放比例，並確保畫面不會捲出盤面；盤面比畫面小時置中
+func (g *GameLayout) clampCamera() {
+	if !g.hasCamera() {
+		g.resetCamera()
+		return
+	}
+	g.camera.Zoom = max(g.minZoom(), min(g.camera.Zoom, maxCameraZoom))
+	viewWidth, viewHeight := g.viewportSize()
+	clampAxis := func(value, boardSize, viewSize float64) float64 {
+		if boardSize <= viewSize {
+			return (boardSize - viewSize) / 2
+		}
+		return max(0, min(value, boardSize-viewSize))
+	}
+	g.camera.X = clampAxis(g.camera.X, float64(gridSize*g.Cols), viewWidth/g.camera.Zoom)
+	g.camera.Y = clampAxis(g.camera.Y, float64(gridSize*g.Rows), viewHeight/g.camera.Zoom)
+}
+
+// pan - 依照畫面上移動的 pixel 捲動盤面
+func (g *GameLayout) pan(delta image.Point) {
+	g.camera.X -= float64(delta.X) / g.camera.Zoom
+	g.camera.Y -= float64(delta.Y) / g.camera.Zoom
+	g.clampCamera()
+}
+
+// zoomAt - 以畫面上的某一點為中心縮放
+func (g *GameLayout) zoomAt(position image.Point, factor float64) {
+	boardX, boardY := g.toBoard(position)
+	g.camera.Zoom *= factor
+	g.clampCamera()
+	g.camera.X = boardX - float64(position.X)/g.camera.Zoom
+	g.camera.Y = boardY - float64(position.Y-PanelHeight)/g.camera.Zoom
+	g.clampCamera()
+}
+
+// centerOn - 將盤面上的某一點移到畫面中央
+func (g *GameLayout) centerOn(boardX, boardY float64) {
+	viewWidth, viewHeight := g.viewportSize()
+	g.camera.X = boardX - viewWidth/g.camera.Zoom/2
+	g.camera.Y = boardY - viewHeight/g.camera.Zoom/2
+	g.clampCamera()
+}
+
+// scrollToCell - 捲動到讓該格子完整出現在畫面上
+func (g *GameLayout) scrollToCell(row, col int) {
+	if !g.hasCamera() {
+		return
+	}
+	viewWidth, viewHeight := g.viewportSize()
+	viewWidth, viewHeight = viewWidth/g.camera.Zoom, viewHeight/g.camera.Zoom
+	cellX, cellY := float64(col*gridSize), float64(row*gridSize)
+	g.camera.X = max(min(g.camera.X, cellX), cellX+gridSize-viewWidth)
+	g.camera.Y = max(min(g.camera.Y, cellY), cellY+gridSize-viewHeight)
+	g.clampCamera()
+}
+
+// toBoard - 將 canvas 座標轉成盤面上的 pixel 位置
+func (g *GameLayout) toBoard(position image.Point) (float64, float64) {
+	return float64(position.X)/g.camera.Zoom + g.camera.X,
+		float64(position.Y-PanelHeight)/g.camera.Zoom + g.camera.Y
+}
+
+// cellOrigin - 格子左上角在盤面圖上的位置
+func (g *GameLayout) cellOrigin(row, col int) (int, int) {
+	return col*gridSize - int(math.Floor(g.camera.X)), row*gridSize - int(math.Floor(g.camera.Y))
+}
+
+// visibleCells - 目前畫面上看得到的格子範圍 (不含 max)
+func (g *GameLayout) visibleCells() (minRow, minCol, maxRow, maxCol int) {
+	viewWidth, viewHeight := g.viewportSize()
+	minRow = max(0, int(math.Floor(g.camera.Y/gridSize)))
+	minCol = max(0, int(math.Floor(g.camera.X/gridSize)))
+	maxRow = min(g.Rows, int(math.Ceil((g.camera.Y+viewHeight/g.camera.Zoom)/gridSize)))
+	maxCol = min(g.Cols, int(math.Ceil((g.camera.X+viewWidth/g.camera.Zoom)/gridSize)))
+	return minRow, minCol, maxRow, maxCol
+}
+
+// updateCamera - 中鍵或手指拖曳捲動、滾輪縮放、鍵盤捲動與縮放、點擊小地圖跳到該位置
+func (g *GameLayout) updateCamera() {
+	if !g.hasCamera() {
+		return
+	}
+	cursor := g.cursorPosition()
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonMiddle) {
+		g.camera.dragFrom, g.camera.dragged = cursor, false
+	}
+	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonMiddle) {
+		delta := cursor.Sub(g.camera.dragFrom)
+		if delta.X*delta.X+delta.Y*delta.Y > touchSlop*touchSlop {
+			g.camera.dragged = true
+		}
+		if g.camera.dragged {
+			g.pan(delta)
+			g.camera.dragFrom = cursor
+		}
+	}
+	for _, press := range g.touches {
+		if press.moved {
+			g.pan(press.drag)
+		}
+	}
+	if _, wheelY := ebiten.Wheel(); wheelY != 0 && cursor.Y >= PanelHeight {
+		g.zoomAt(cursor, math.Pow(wheelZoomStep, wheelY))
+	}
+	viewWidth, viewHeight := g.viewportSize()
+	center := image.Pt(int(viewWidth/2), PanelHeight+int(viewHeight/2))
+	switch {
+	case g.actionJustPressed(ActionZoomIn):
+		g.zoomAt(center, 1.25)
+	case g.actionJustPressed(ActionZoomOut):
+		g.zoomAt(center, 1/1.25)
+	}
+	page := image.Pt(int(viewWidth*0.8), int(viewHeight*0.8))
+	switch {
+	case g.actionRepeated(ActionScrollUp):
+		g.pan(image.Pt(0, page.Y))
+	case g.actionRepeated(ActionScrollDown):
+		g.pan(image.Pt(0, -page.Y))
+	case g.actionRepeated(ActionScrollLeft):
+		g.pan(image.Pt(page.X, 0))
+	case g.actionRepeated(ActionScrollRight):
+		g.pan(image.Pt(-page.X, 0))
+	}
+	// 按住小地圖可以直接拖曳可視範圍
+	if (ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) && cursor.In(g.minimapRect())) ||
+		(g.tap != nil && g.tap.In(g.minimapRect())) {
+		position := cursor
+		if g.tap != nil {
+			position = *g.tap
+		}
+		rect := g.minimapRect()
+		g.centerOn(
+			float64(position.X-rect.Min.X)/float64(rect.Dx())*float64(gridSize*g.Cols),
+			float64(position.Y-rect.Min.Y)/float64(rect.Dy())*float64(gridSize*g.Rows),
+		)
+	}
+}
+
+// minimapRect - 小地圖位於盤面右下角，依照盤面比例決定長寬；沒有捲動時為空
+func (g *GameLayout) minimapRect() image.Rectangle {
+	if !g.hasCamera() {
+		return image.Rectangle{}
+	}
+	cellSize := min(float64(minimapSize)/float64(g.Cols), float64(minimapSize)/float64(g.Rows))
+	width, height := int(cellSize*float64(g.Cols)), int(cellSize*float64(g.Rows))
+	maxX, maxY := g.ScreenWidth-minimapMargin, g.ScreenHeight-minimapMargin
+	return image.Rect(maxX-width, maxY-height, maxX, maxY)
+}
+
+// drawBoardView - 只畫出可視範圍內的格子，再依照縮放比例畫到面板下方
+func (g *GameLayout) drawBoardView(screen *ebiten.Image) {
+	viewWidth, viewHeight := g.viewportSize()
+	// 縮到最小時需要的盤面圖大小，之後只取用其中一部分
+	imageWidth := int(math.Ceil(viewWidth/g.minZoom())) + gridSize
+	imageHeight := int(math.Ceil(viewHeight/g.minZoom())) + gridSize
+	if g.boardImage == nil || g.boardImage.Bounds().Dx() != imageWidth || g.boardImage.Bounds().Dy() != imageHeight {
+		if g.boardImage != nil {
+			g.boardImage.Deallocate()
+		}
+		g.boardImage = ebiten.NewImage(imageWidth, imageHeight)
+	}
+	g.boardImage.Clear()
+	g.drawBoard(g.boardImage)
+	g.drawCellAnimations(g.boardImage)
+	g.drawReview(g.boardImage)
+	g.drawHint(g.boardImage)
+	g.drawExplodedHighlight(g.boardImage)
+	g.drawPlayerCursors(g.boardImage)
+
+	visible := image.Rect(0, 0,
+		int(math.Ceil(viewWidth/g.camera.Zoom))+1,
+		int(math.Ceil(viewHeight/g.camera.Zoom))+1,
+	)
+	options := &ebiten.DrawImageOptions{}
+	// cellOrigin 只取整數位移，剩下的小數部分在這裡補上
+	options.GeoM.Translate(math.Floor(g.camera.X)-g.camera.X, math.Floor(g.camera.Y)-g.camera.Y)
+	options.GeoM.Scale(g.camera.Zoom, g.camera.Zoom)
+	options.GeoM.Translate(0, PanelHeight)
+	options.Filter = ebiten.FilterLinear
+	view := screen.SubImage(image.Rect(0, PanelHeight, int(viewWidth), PanelHeight+int(viewHeight))).(*ebiten.Image)
+	view.DrawImage(g.boardImage.SubImage(visible).(*ebiten.Image), options)
+}
+
+// drawMinimap - 在右下角畫出整個盤面的縮圖與目前的可視範圍
+func (g *GameLayout) drawMinimap(screen *ebiten.Image) {
+	rect := g.minimapRect()
+	if rect.Empty() {
+		return
+	}
+	if g.minimapImage == nil || g.minimapImage.Bounds().Dx() != g.Cols || g.minimapImage.Bounds().Dy() != g.Rows {
+		if g.minimapImage != nil {
+			g.minimapImage.Deallocate()
+		}
+		g.minimapImage = ebiten.NewImage(g.Cols, g.Rows)
+		g.minimapPixels = make([]byte, 4*g.Cols*g.Rows)
+	}
+	// 每一格對應一個 pixel
+	for row := 0; row < g.Rows; row++ {
+		for col := 0; col < g.Cols; col++ {
+			cell := g.gameInstance.Board.GetCell(row, col)
+			pixel := g.theme.CoveredCell
+			switch {
+			case cell.Flagged:
+				pixel = g.theme.ExplodedMine
+			case cell.Revealed && cell.IsMine:
+				pixel = g.theme.RevealedMine
+			case cell.Revealed:
+				pixel = g.theme.RevealedCell
+			}
+			offset := 4 * (row*g.Cols + col)
+			g.minimapPixels[offset] = pixel.R
+			g.minimapPixels[offset+1] = pixel.G
+			g.minimapPixels[offset+2] = pixel.B
+			g.minimapPixels[offset+3] = pixel.A
+		}
+	}
+	g.minimapImage.WritePixels(g.minimapPixels)
+
+	vector.DrawFilledRect(screen, float32(rect.Min.X-2), float32(rect.Min.Y-2),
+		float32(rect.Dx()+4), float32(rect.Dy()+4), withAlpha(g.theme.Overlay, 0xc0), false)
+	options := &ebiten.DrawImageOptions{}
+	options.GeoM.Scale(float64(rect.Dx())/float64(g.Cols), float64(rect.Dy())/float64(g.Rows))
+	options.GeoM.Translate(float64(rect.Min.X), float64(rect.Min.Y))
+	options.ColorScale.ScaleAlpha(0.85)
+	screen.DrawImage(g.minimapImage, options)
+
+	// 目前的可視範圍
+	viewWidth, viewHeight := g.viewportSize()
+	scaleX := float64(rect.Dx()) / float64(gridSize*g.Cols)
+	scaleY := float64(rect.Dy()) / float64(gridSize*g.Rows)
+	minX := max(float64(rect.Min.X), float64(rect.Min.X)+g.camera.X*scaleX)
+	minY := max(float64(rect.Min.Y), float64(rect.Min.Y)+g.camera.Y*scaleY)
+	maxX := min(float64(rect.Max.X), float64(rect.Min.X)+(g.camera.X+viewWidth/g.camera.Zoom)*scaleX)
+	maxY := min(float64(rect.Max.Y), float64(rect.Min.Y)+(g.camera.Y+viewHeight/g.camera.Zoom)*scaleY)
+	vector.StrokeRect(screen, float32(minX), float32(minY), float32(maxX-minX), float32(maxY-minY),
+		1.5, g.theme.Highlight, false)
+}
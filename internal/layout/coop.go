@@ -0,0 +1,101 @@
+package layout
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/coop"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+)
+
+// playerColors - 合作模式玩家游標顏色，依 Player.ColorIndex 循環使用
+var playerColors = []color.RGBA{
+	{0x00, 0x72, 0xff, 0xff},
+	{0xff, 0x40, 0x40, 0xff},
+	{0x00, 0xb0, 0x40, 0xff},
+	{0xff, 0x90, 0x00, 0xff},
+	{0xa0, 0x40, 0xff, 0xff},
+	{0x00, 0xc0, 0xc0, 0xff},
+}
+
+// NewRemoteGameLayout - 建立連線到合作模式 server 的遊戲畫面
+func NewRemoteGameLayout(client *coop.Client) *GameLayout {
+	gameLayout := NewGameLayout(game.NewGame(DefaultRows, DefaultCols, 0))
+	gameLayout.remote = client
+	// 合作模式直接進入盤面
+	gameLayout.scenes = nil
+	return gameLayout
+}
+
+// syncRemote - 以 server 最新快照更新畫面狀態，並回報自己的游標位置
+func (g *GameLayout) syncRemote() {
+	snapshot := g.remote.Snapshot()
+	if snapshot == nil {
+		return
+	}
+	g.gameInstance = snapshot.Game()
+	resized := g.Rows != snapshot.Rows || g.Cols != snapshot.Cols
+	g.Rows = snapshot.Rows
+	g.Cols = snapshot.Cols
+	if resized {
+		g.resizeWindow()
+		g.clampCamera()
+	}
+	g.MineCounts = snapshot.MineCounts
+	g.elapsedTime = snapshot.ElapsedSeconds
+
+	row, col, ok := g.cellAt(g.cursorPosition())
+	if !ok {
+		row, col = -1, -1
+	}
+	// 使用鍵盤時改回報鍵盤游標的位置
+	if g.cursor.Visible {
+		g.cursor.clamp(g.Rows, g.Cols)
+		row, col = g.cursor.Row, g.cursor.Col
+	}
+	self := g.remote.Player()
+	for _, player := range snapshot.Players {
+		if player.ID == self.ID && (player.CursorRow != row || player.CursorCol != col) {
+			g.remote.MoveCursor(row, col)
+		}
+	}
+}
+
+// drawPlayerCursors - 以各自顏色畫出其他玩家的游標與名字
+func (g *GameLayout) drawPlayerCursors(screen *ebiten.Image) {
+	if g.remote == nil {
+		return
+	}
+	snapshot := g.remote.Snapshot()
+	if snapshot == nil {
+		return
+	}
+	self := g.remote.Player()
+	for _, player := range snapshot.Players {
+		if player.ID == self.ID || player.CursorRow < 0 || player.CursorCol < 0 {
+			continue
+		}
+		playerColor := playerColors[player.ColorIndex%len(playerColors)]
+		x, y := g.cellOrigin(player.CursorRow, player.CursorCol)
+		vector.StrokeRect(screen,
+			float32(x)+1,
+			float32(y)+1,
+			gridSize-3,
+			gridSize-3,
+			3,
+			playerColor,
+			false,
+		)
+		textOpts := &text.DrawOptions{}
+		textOpts.ColorScale.ScaleWithColor(playerColor)
+		textOpts.PrimaryAlign = text.AlignStart
+		textOpts.SecondaryAlign = text.AlignEnd
+		textOpts.GeoM.Translate(float64(x), float64(y))
+		text.Draw(screen, player.Name, &text.GoTextFace{
+			Source: g.theme.TextFont,
+			Size:   12,
+		}, textOpts)
+	}
+}
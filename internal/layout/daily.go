@@ -0,0 +1,161 @@
+package layout
+
+import (
+	"fmt"
+	"image"
+	"log"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+)
+
+// Mode - 遊戲模式
+type Mode int
+
+const (
+	ModeClassic Mode = iota // 隨機盤面
+	ModeDaily               // 每日挑戰，盤面由日期決定
+)
+
+var ModeIconMap map[Mode]string = map[Mode]string{
+	ModeClassic: "🎲",
+	ModeDaily:   "📅",
+}
+
+var ModeMessage map[Mode]string = map[Mode]string{
+	ModeClassic: "Classic",
+	ModeDaily:   "Daily",
+}
+
+// dailyHistoryLines - 每日挑戰紀錄畫面顯示的筆數
+const dailyHistoryLines = 10
+
+// newGameInstance - 依照模式與難度建立新的遊戲
+func (g *GameLayout) newGameInstance() *game.Game {
+	g.replay = g.mode == ModeClassic && (g.nextGame != nil || g.seed != 0)
+	if g.mode == ModeDaily {
+		g.dailyDate = time.Now()
+		g.dailyScored = !g.daily.HasAttempt(g.dailyDate, g.level)
+		return game.NewDailyGame(g.dailyDate, g.level)
+	}
+	if g.nextGame != nil {
+		instance := g.nextGame
+		g.nextGame = nil
+		return instance
+	}
+	if g.seed != 0 {
+		seed := g.seed
+		g.seed = 0
+		return game.NewGameWithSeed(g.Rows, g.Cols, g.MineCounts, seed)
+	}
+	return game.NewGame(g.Rows, g.Cols, g.MineCounts)
+}
+
+// windowTitle - 視窗標題，每日挑戰會標示日期以及是否計分
+func (g *GameLayout) windowTitle() string {
+	if g.mode != ModeDaily && g.custom != nil {
+		return fmt.Sprintf("Custom %dx%d Mine Sweeper Grid", g.custom.Rows, g.custom.Cols)
+	}
+	if g.mode != ModeDaily {
+		return fmt.Sprintf("%s Mine Sweeper Grid", LevelMessage[g.level])
+	}
+	attempt := "scored"
+	if !g.dailyScored {
+		attempt = "practice"
+	}
+	return fmt.Sprintf("Daily %s %s Mine Sweeper Grid (%s)",
+		g.dailyDate.Format(game.DailyDateFormat), LevelMessage[g.level], attempt)
+}
+
+// dailyListener - 每日挑戰在第一次動作時用掉當天的計分機會，結束時記錄結果
+func (g *GameLayout) dailyListener() game.EventListener {
+	date, level, gameInstance, scored := g.dailyDate, g.level, g.gameInstance, g.dailyScored
+	return func(event game.Event) {
+		if !scored {
+			return
+		}
+		switch event.Type {
+		case game.EventReveal, game.EventFlag:
+			if !g.daily.Start(date, level, gameInstance.Seed) {
+				return
+			}
+		case game.EventWin, game.EventExplode:
+			g.daily.Finish(date, level, event.Type == game.EventWin, gameInstance.GetElapsedDuration())
+		default:
+			return
+		}
+		if err := g.daily.Save(); err != nil {
+			log.Printf("daily: %v", err)
+		}
+	}
+}
+
+// modeButtonRect - 模式切換按鈕位於左上角 Level 文字右側
+func (g *GameLayout) modeButtonRect() image.Rectangle {
+	return image.Rect(64, 2, 64+gridSize, 2+gridSize)
+}
+
+// isModeButtonClicked - 是否剛點擊模式切換按鈕
+func (g *GameLayout) isModeButtonClicked() bool {
+	position, clicked := g.clickPosition()
+	return clicked && position.In(g.modeButtonRect())
+}
+
+// ChangeMode - 切換遊戲模式
+func (g *GameLayout) ChangeMode() {
+	g.mode = (g.mode + 1) % Mode(len(ModeIconMap))
+}
+
+// drawModeButton - 繪製模式切換按鈕
+func (g *GameLayout) drawModeButton(screen *ebiten.Image) {
+	rect := g.modeButtonRect()
+	vector.DrawFilledRect(screen,
+		float32(rect.Min.X),
+		float32(rect.Min.Y),
+		float32(rect.Dx()),
+		float32(rect.Dy()),
+		g.theme.Button,
+		true,
+	)
+	drawTextAt(screen, ModeIconMap[g.mode], g.theme.IconFont, 24,
+		float64(rect.Min.X+rect.Dx()/2), float64(rect.Min.Y+rect.Dy()/2),
+		g.theme.ButtonIcon, text.AlignCenter)
+}
+
+// drawDailyHistoryScene - 繪製本機的每日挑戰紀錄
+func (g *GameLayout) drawDailyHistoryScene(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, 0, PanelHeight, float32(g.ScreenWidth), float32(g.ScreenHeight-PanelHeight),
+		g.theme.Overlay, false)
+	centerX := float64(g.ScreenWidth) / 2
+	drawTextAt(screen, "Daily challenges", g.theme.TextFont, 20,
+		centerX, PanelHeight+gridSize/2, g.theme.OverlayText, text.AlignCenter)
+
+	attempts := g.daily.Recent(dailyHistoryLines)
+	if len(attempts) == 0 {
+		drawTextAt(screen, "No daily challenges yet", g.theme.TextFont, 14,
+			centerX, PanelHeight+2*gridSize, g.theme.OverlayText, text.AlignCenter)
+	}
+	lineHeight := 20.0
+	for index, attempt := range attempts {
+		y := PanelHeight + 1.5*gridSize + float64(index)*lineHeight
+		result := "DNF"
+		switch {
+		case attempt.Finished && attempt.Won:
+			result = fmt.Sprintf("%.3fs", attempt.Duration().Seconds())
+		case attempt.Finished:
+			result = "💥"
+		}
+		drawTextAt(screen, attempt.Date[5:], g.theme.TextFont, 14, 8, y, g.theme.OverlayText, text.AlignStart)
+		drawTextAt(screen, LevelMessage[attempt.Level], g.theme.TextFont, 14, 64, y, LevelColorMap[attempt.Level], text.AlignStart)
+		source := g.theme.TextFont
+		if attempt.Finished && !attempt.Won {
+			source = g.theme.IconFont
+		}
+		drawTextAt(screen, result, source, 14, float64(g.ScreenWidth)-8, y, g.theme.OverlayText, text.AlignEnd)
+	}
+	drawTextAt(screen, "Esc close", g.theme.TextFont, 12,
+		centerX, float64(g.ScreenHeight)-12, g.theme.OverlayText, text.AlignCenter)
+}
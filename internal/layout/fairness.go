@@ -0,0 +1,117 @@
+package layout
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+)
+
+// shortCommitmentLength - 視窗標題顯示的承諾雜湊長度
+const shortCommitmentLength = 12
+
+// fairnessTitle - 視窗標題加上承諾雜湊的開頭，遊戲結束後改為公開的 seed
+func (g *GameLayout) fairnessTitle() string {
+	commitment := g.gameInstance.Commitment()
+	if g.remote != nil || commitment == "" {
+		return g.windowTitle()
+	}
+	if proof, revealed := g.gameInstance.Proof(); revealed {
+		return fmt.Sprintf("%s - seed %d", g.windowTitle(), proof.Seed)
+	}
+	return fmt.Sprintf("%s - #%s", g.windowTitle(), commitment[:shortCommitmentLength])
+}
+
+// logCommitment - 遊戲開始前把完整的承諾雜湊寫到 log，方便在比賽中先行公開
+func (g *GameLayout) logCommitment() {
+	if g.remote != nil || g.gameInstance.Commitment() == "" {
+		return
+	}
+	log.Printf("fairness: commitment %s", g.gameInstance.Commitment())
+}
+
+// verifyCommand - 驗證這局盤面的指令，盤面不是由 seed 產生時需要提供盤面檔案
+func verifyCommand(proof game.Proof, rows, cols, mines int) string {
+	if proof.Seed == 0 {
+		return fmt.Sprintf("go run ./cmd/minesweeper-verify -commitment %s -salt %s -load <layout file>",
+			proof.Commitment, proof.Salt)
+	}
+	return fmt.Sprintf("go run ./cmd/minesweeper-verify -commitment %s -seed %d -salt %s -rows %d -cols %d -mines %d",
+		proof.Commitment, proof.Seed, proof.Salt, rows, cols, mines)
+}
+
+// fairnessListener - 遊戲結束時公開 seed 與 salt，並寫出驗證指令
+func (g *GameLayout) fairnessListener() game.EventListener {
+	return func(event game.Event) {
+		if event.Type != game.EventWin && event.Type != game.EventExplode {
+			return
+		}
+		proof, revealed := g.gameInstance.Proof()
+		if !revealed || proof.Commitment == "" {
+			return
+		}
+		ebiten.SetWindowTitle(g.fairnessTitle())
+		log.Printf("fairness: seed %d salt %s, verify with: %s", proof.Seed, proof.Salt,
+			verifyCommand(proof, g.gameInstance.Board.Rows, g.gameInstance.Board.Cols, g.gameInstance.MineCounts))
+	}
+}
+
+// openFairness - 顯示目前這局的承諾雜湊，結束後一併顯示 seed 與 salt
+func (g *GameLayout) openFairness() {
+	g.pushScene(SceneFairness)
+	if _, revealed := g.gameInstance.Proof(); revealed {
+		g.announce("fair play, seed and salt revealed")
+		return
+	}
+	g.announce("fair play, seed hidden until the game ends")
+}
+
+// updateFairnessScene - Esc、Enter 或點擊關閉
+func (g *GameLayout) updateFairnessScene() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) || g.actionJustPressed(ActionReveal) || g.isClicked() {
+		g.popScene()
+	}
+}
+
+// drawFairnessScene - 繪製承諾雜湊、公開的 seed 與 salt 以及驗證方式
+func (g *GameLayout) drawFairnessScene(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, 0, PanelHeight, float32(g.ScreenWidth), float32(g.ScreenHeight-PanelHeight),
+		g.theme.Overlay, false)
+	centerX := float64(g.ScreenWidth) / 2
+	drawTextAt(screen, "Fair play", g.theme.TextFont, 20,
+		centerX, PanelHeight+gridSize/2, g.theme.OverlayText, text.AlignCenter)
+
+	commitment := g.gameInstance.Commitment()
+	seed, salt := "hidden until the game ends", "hidden until the game ends"
+	if proof, revealed := g.gameInstance.Proof(); revealed {
+		seed, salt = fmt.Sprintf("%d", proof.Seed), proof.Salt
+	}
+	// 雜湊分成兩行顯示
+	half := len(commitment) / 2
+	lines := [][2]string{
+		{"Commitment (SHA-256)", ""},
+		{"", commitment[:half]},
+		{"", commitment[half:]},
+		{"Seed", ""},
+		{"", seed},
+		{"Salt", ""},
+		{"", salt},
+	}
+	lineHeight := 18.0
+	for index, line := range lines {
+		y := PanelHeight + gridSize + 4 + float64(index)*lineHeight
+		if line[0] != "" {
+			drawTextAt(screen, line[0], g.theme.TextFont, 14, 8, y, g.theme.Highlight, text.AlignStart)
+			continue
+		}
+		drawTextAt(screen, line[1], g.theme.TextFont, 12, centerX, y, g.theme.OverlayText, text.AlignCenter)
+	}
+	drawTextAt(screen, "Verify with cmd/minesweeper-verify", g.theme.TextFont, 12,
+		centerX, float64(g.ScreenHeight)-28, g.theme.OverlayText, text.AlignCenter)
+	drawTextAt(screen, "Esc or click to close", g.theme.TextFont, 12,
+		centerX, float64(g.ScreenHeight)-12, g.theme.OverlayText, text.AlignCenter)
+}
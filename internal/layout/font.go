@@ -5,46 +5,35 @@ import (
 	"image/color"
 	"log"
 
+	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 	"github.com/leetcode-golang-classroom/mine-sweeper/internal/fonts"
 )
 
 var (
-	mplusFaceSource *text.GoTextFaceSource
-	// emojiFace       font.Face
-	emojiFaceSource *text.GoTextFaceSource
+	mplusFaceSource        = mustFaceSource(fonts.MPlus1pRegular_ttf)
+	emojiFaceSource        = mustFaceSource(fonts.NotoEmojiRegular_ttf)
+	pressStart2PFaceSource = mustFaceSource(fonts.PressStart2P_ttf)
 )
 
-func init() {
-	s, err := text.NewGoTextFaceSource(bytes.NewReader(fonts.MPlus1pRegular_ttf))
+// mustFaceSource - 讀取內嵌字型，宣告成 package 變數讓 Theme 初始化時字型已經準備好
+func mustFaceSource(data []byte) *text.GoTextFaceSource {
+	source, err := text.NewGoTextFaceSource(bytes.NewReader(data))
 	if err != nil {
 		log.Fatal(err)
 	}
-	mplusFaceSource = s
-
-	s, err = text.NewGoTextFaceSource(bytes.NewReader(fonts.NotoEmojiRegular_ttf))
-	if err != nil {
-		log.Fatal(err)
-	}
-	emojiFaceSource = s
+	return source
 }
 
-const (
-	IsMine = iota + 100
-	IsFlag
-	IsButtonIcon
-	IsClock
-)
-
-func getTileColor(value int) color.Color {
-	switch value {
-	case 0:
-		return color.RGBA{0x77, 0x6e, 0x65, 0xff}
-	case IsMine, IsButtonIcon:
-		return color.Black
-	case IsClock, IsFlag:
-		return color.RGBA{0xff, 0, 0, 0xff}
-	default:
-		return color.RGBA{0xf9, 0xf6, 0xf2, 0xff}
-	}
+// drawTextAt - 以指定字型、大小、顏色與水平對齊方式在 (x, y) 垂直置中繪製文字
+func drawTextAt(screen *ebiten.Image, value string, source *text.GoTextFaceSource, size float64, x, y float64, clr color.Color, align text.Align) {
+	textOpts := &text.DrawOptions{}
+	textOpts.ColorScale.ScaleWithColor(clr)
+	textOpts.PrimaryAlign = align
+	textOpts.SecondaryAlign = text.AlignCenter
+	textOpts.GeoM.Translate(x, y)
+	text.Draw(screen, value, &text.GoTextFace{
+		Source: source,
+		Size:   size,
+	}, textOpts)
 }
@@ -0,0 +1,129 @@
+package layout
+
+import (
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// stickThreshold - 類比搖桿超過這個值才視為推向該方向
+const stickThreshold = 0.5
+
+// GamepadBindings - 每個動作對應的標準手把按鈕
+type GamepadBindings map[InputAction][]ebiten.StandardGamepadButton
+
+// DefaultGamepadBindings - 預設按鈕：十字鍵移動、A 翻開、X 插旗、Y chord、Start 重新開始、Back 切換難度、LB 提示
+func DefaultGamepadBindings() GamepadBindings {
+	return GamepadBindings{
+		ActionUp:          {ebiten.StandardGamepadButtonLeftTop},
+		ActionDown:        {ebiten.StandardGamepadButtonLeftBottom},
+		ActionLeft:        {ebiten.StandardGamepadButtonLeftLeft},
+		ActionRight:       {ebiten.StandardGamepadButtonLeftRight},
+		ActionReveal:      {ebiten.StandardGamepadButtonRightBottom},
+		ActionFlag:        {ebiten.StandardGamepadButtonRightLeft, ebiten.StandardGamepadButtonRightRight},
+		ActionChord:       {ebiten.StandardGamepadButtonRightTop},
+		ActionRestart:     {ebiten.StandardGamepadButtonCenterRight},
+		ActionChangeLevel: {ebiten.StandardGamepadButtonCenterLeft},
+		ActionReview:      {ebiten.StandardGamepadButtonRightTop},
+		ActionHint:        {ebiten.StandardGamepadButtonFrontTopLeft},
+	}
+}
+
+// gamepadState - 一個已連接手把的類比搖桿狀態
+type gamepadState struct {
+	dRow   int // 搖桿目前的垂直方向 (-1, 0, 1)
+	dCol   int // 搖桿目前的水平方向 (-1, 0, 1)
+	frames int // 維持同一方向的 frame 數
+}
+
+// isRepeatFrame - 按住 duration 個 frame 時是否要觸發一次移動
+func isRepeatFrame(duration int) bool {
+	return duration == 1 || (duration > keyRepeatDelay && (duration-keyRepeatDelay)%keyRepeatInterval == 0)
+}
+
+// stickDirection - 將搖桿數值轉成 -1, 0, 1
+func stickDirection(value float64) int {
+	switch {
+	case value <= -stickThreshold:
+		return -1
+	case value >= stickThreshold:
+		return 1
+	}
+	return 0
+}
+
+// SetGamepadBindings - 設定手把按鈕，未設定的動作沿用預設按鈕
+func (g *GameLayout) SetGamepadBindings(bindings GamepadBindings) {
+	g.gamepadBindings = DefaultGamepadBindings()
+	for action, buttons := range bindings {
+		g.gamepadBindings[action] = buttons
+	}
+}
+
+// updateGamepads - 同步目前連接的手把 (支援遊戲中插拔) 並更新搖桿狀態
+func (g *GameLayout) updateGamepads() {
+	connected := map[ebiten.GamepadID]bool{}
+	for _, id := range ebiten.AppendGamepadIDs(nil) {
+		connected[id] = true
+		state, ok := g.gamepads[id]
+		if !ok {
+			if !ebiten.IsStandardGamepadLayoutAvailable(id) {
+				continue
+			}
+			log.Printf("gamepad connected: %s (%d)", ebiten.GamepadName(id), id)
+			state = &gamepadState{}
+			g.gamepads[id] = state
+		}
+		dRow := stickDirection(ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickVertical))
+		dCol := stickDirection(ebiten.StandardGamepadAxisValue(id, ebiten.StandardGamepadAxisLeftStickHorizontal))
+		if dRow == 0 && dCol == 0 {
+			state.frames = 0
+		} else if dRow == state.dRow && dCol == state.dCol {
+			state.frames++
+		} else {
+			state.frames = 1
+		}
+		state.dRow, state.dCol = dRow, dCol
+	}
+	for id := range g.gamepads {
+		if !connected[id] {
+			log.Printf("gamepad disconnected: %d", id)
+			delete(g.gamepads, id)
+		}
+	}
+}
+
+// gamepadJustPressed - 任一手把剛按下該動作的按鈕
+func (g *GameLayout) gamepadJustPressed(action InputAction) bool {
+	for id := range g.gamepads {
+		for _, button := range g.gamepadBindings[action] {
+			if inpututil.IsStandardGamepadButtonJustPressed(id, button) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// gamepadRepeated - 任一手把的按鈕或搖桿在該方向上需要觸發一次移動
+func (g *GameLayout) gamepadRepeated(action InputAction) bool {
+	for id, state := range g.gamepads {
+		for _, button := range g.gamepadBindings[action] {
+			if isRepeatFrame(inpututil.StandardGamepadButtonPressDuration(id, button)) {
+				return true
+			}
+		}
+		if state.frames == 0 || !isRepeatFrame(state.frames) {
+			continue
+		}
+		switch {
+		case action == ActionUp && state.dRow < 0,
+			action == ActionDown && state.dRow > 0,
+			action == ActionLeft && state.dCol < 0,
+			action == ActionRight && state.dCol > 0:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,155 @@
+package layout
+
+import (
+	"fmt"
+	"image"
+	"math"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/solver"
+)
+
+const (
+	hintPenaltyStep = 5  // 設定畫面每次調整的提示懲罰秒數
+	maxHintPenalty  = 60 // 提示懲罰的上限秒數
+	hintPulseFrames = 30 // 提示外框閃爍的週期
+)
+
+// hint - 目前顯示在盤面上的提示
+type hint struct {
+	row, col    int
+	probability float64 // 該格是地雷的機率，0 代表可以證明安全
+}
+
+// hintButtonRect - 提示按鈕位於重新開始按鈕右側，與設定按鈕對稱
+func (g *GameLayout) hintButtonRect() image.Rectangle {
+	restart := g.restartButtonRect()
+	return image.Rect(restart.Max.X+4, gridSize+2, restart.Max.X+4+gridSize, 2*gridSize+2)
+}
+
+// isHintButtonClicked - 是否剛點擊提示按鈕
+func (g *GameLayout) isHintButtonClicked() bool {
+	position, clicked := g.clickPosition()
+	return clicked && position.In(g.hintButtonRect())
+}
+
+// SetHintPenalty - 設定每次使用提示加上的秒數並寫回設定檔
+func (g *GameLayout) SetHintPenalty(seconds int) {
+	g.settings.HintPenalty = min(max(seconds, 0), maxHintPenalty)
+	g.saveSettings()
+}
+
+// findHint - 以 solver 找出可以證明安全的格子，沒有時找地雷機率最低的格子，相同時選離游標最近的
+func (g *GameLayout) findHint() (hint, bool) {
+	board := g.gameInstance.Board
+	analysis := solver.Analyze(board.VisibleRows(), g.gameInstance.MineCounts)
+	best, bestDistance, found := hint{}, math.MaxInt, false
+	consider := func(row, col int, probability float64) {
+		// 插旗的格子即使推論安全也不建議，避免玩家以為旗子插錯
+		if board.GetCell(row, col).Flagged {
+			return
+		}
+		distance := max(row-g.cursor.Row, g.cursor.Row-row) + max(col-g.cursor.Col, g.cursor.Col-col)
+		if found && (probability > best.probability || (probability == best.probability && distance >= bestDistance)) {
+			return
+		}
+		best, bestDistance, found = hint{row: row, col: col, probability: probability}, distance, true
+	}
+	for _, cell := range analysis.Safe {
+		consider(cell.Row, cell.Col, 0)
+	}
+	if found {
+		return best, true
+	}
+	for row := range analysis.Probability {
+		for col, probability := range analysis.Probability[row] {
+			if probability >= 0 {
+				consider(row, col, probability)
+			}
+		}
+	}
+	return best, found
+}
+
+// RequestHint - 在盤面上標示提示的格子並加上懲罰時間，這局會在統計中標記為有輔助
+func (g *GameLayout) RequestHint() {
+	if g.remote != nil || g.gameInstance.IsGameOver || g.gameInstance.IsPlayerWin {
+		return
+	}
+	// 上一個提示還沒用掉時不重複扣時間
+	if g.hint == nil {
+		found, ok := g.findHint()
+		if !ok {
+			return
+		}
+		g.hint = &found
+		g.gameInstance.UseHint(found.row, found.col, time.Duration(g.settings.HintPenalty)*time.Second)
+	}
+	g.scrollToCell(g.hint.row, g.hint.col)
+	if g.cursor.Visible {
+		g.cursor.Row, g.cursor.Col = g.hint.row, g.hint.col
+	}
+	g.announce(g.hintDescription())
+}
+
+// hintDescription - 提示的說明文字
+func (g *GameLayout) hintDescription() string {
+	position := fmt.Sprintf("row %d column %d", g.hint.row+1, g.hint.col+1)
+	if g.hint.probability == 0 {
+		return fmt.Sprintf("hint: %s is safe", position)
+	}
+	return fmt.Sprintf("hint: no safe cell, %s has the lowest risk, %.0f%% chance of a mine", position, 100*g.hint.probability)
+}
+
+// updateHint - 提示的格子被翻開或插旗後就不再顯示
+func (g *GameLayout) updateHint() {
+	if g.hint == nil {
+		return
+	}
+	cell := g.gameInstance.Board.GetCell(g.hint.row, g.hint.col)
+	if cell.Revealed || cell.Flagged || g.gameInstance.IsGameOver || g.gameInstance.IsPlayerWin {
+		g.hint = nil
+	}
+}
+
+// drawHintButton - 繪製提示按鈕，使用過提示時下方顯示次數
+func (g *GameLayout) drawHintButton(screen *ebiten.Image) {
+	rect := g.hintButtonRect()
+	vector.DrawFilledRect(screen,
+		float32(rect.Min.X),
+		float32(rect.Min.Y),
+		float32(rect.Dx()),
+		float32(rect.Dy()),
+		g.theme.Button,
+		true,
+	)
+	drawTextAt(screen, "💡", g.theme.IconFont, 24,
+		float64(rect.Min.X+rect.Dx()/2), float64(rect.Min.Y+rect.Dy()/2),
+		g.theme.ButtonIcon, text.AlignCenter)
+	if hints := g.gameInstance.Hints; hints > 0 {
+		drawTextAt(screen, fmt.Sprintf("%d", hints), g.theme.TextFont, 10,
+			float64(rect.Max.X-2), float64(rect.Max.Y-6), g.theme.ButtonIcon, text.AlignEnd)
+	}
+}
+
+// drawHint - 在提示的格子上畫出閃爍的外框，不確定安全時顯示地雷機率
+func (g *GameLayout) drawHint(screen *ebiten.Image) {
+	if g.hint == nil {
+		return
+	}
+	x, y := g.cellOrigin(g.hint.row, g.hint.col)
+	pulse := 0.5 + 0.5*math.Sin(2*math.Pi*float64(g.animation.tick)/hintPulseFrames)
+	clr := g.theme.Highlight
+	if g.hint.probability > 0 {
+		clr = g.theme.ReviewMine
+		clr.A = 0xff
+	}
+	vector.StrokeRect(screen, float32(x)+1, float32(y)+1, gridSize-3, gridSize-3, float32(2+2*pulse), clr, false)
+	if g.hint.probability > 0 {
+		drawTextAt(screen, fmt.Sprintf("%.0f%%", 100*g.hint.probability), g.theme.TextFont, 10,
+			float64(x+gridSize/2), float64(y+gridSize/2), g.theme.OverlayText, text.AlignCenter)
+	}
+}
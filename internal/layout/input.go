@@ -0,0 +1,188 @@
+package layout
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// InputAction - 可以綁定按鍵的動作，字串值同時作為設定檔中的名稱
+type InputAction string
+
+const (
+	ActionUp          InputAction = "up"
+	ActionDown        InputAction = "down"
+	ActionLeft        InputAction = "left"
+	ActionRight       InputAction = "right"
+	ActionReveal      InputAction = "reveal"
+	ActionFlag        InputAction = "flag"
+	ActionChord       InputAction = "chord"
+	ActionRestart     InputAction = "restart"
+	ActionChangeLevel InputAction = "changeLevel"
+	ActionFullscreen  InputAction = "fullscreen"
+	ActionZoomIn      InputAction = "zoomIn"
+	ActionZoomOut     InputAction = "zoomOut"
+	ActionScrollUp    InputAction = "scrollUp"
+	ActionScrollDown  InputAction = "scrollDown"
+	ActionScrollLeft  InputAction = "scrollLeft"
+	ActionScrollRight InputAction = "scrollRight"
+	ActionReview      InputAction = "review"
+	ActionHint        InputAction = "hint"
+)
+
+const (
+	keyRepeatDelay    = 15 // 按住多少個 frame 之後開始連續移動
+	keyRepeatInterval = 4  // 連續移動的間隔 frame 數
+)
+
+// KeyBindings - 每個動作對應的按鍵，ebiten.Key 會以按鍵名稱序列化成 JSON
+type KeyBindings map[InputAction][]ebiten.Key
+
+// DefaultKeyBindings - 預設按鍵：方向鍵、WASD 與 vim 的 hjkl 都可以移動游標
+func DefaultKeyBindings() KeyBindings {
+	return KeyBindings{
+		ActionUp:          {ebiten.KeyArrowUp, ebiten.KeyW, ebiten.KeyK},
+		ActionDown:        {ebiten.KeyArrowDown, ebiten.KeyS, ebiten.KeyJ},
+		ActionLeft:        {ebiten.KeyArrowLeft, ebiten.KeyA, ebiten.KeyH},
+		ActionRight:       {ebiten.KeyArrowRight, ebiten.KeyD, ebiten.KeyL},
+		ActionReveal:      {ebiten.KeySpace, ebiten.KeyEnter},
+		ActionFlag:        {ebiten.KeyF},
+		ActionChord:       {ebiten.KeyC},
+		ActionRestart:     {ebiten.KeyR},
+		ActionChangeLevel: {ebiten.KeyN},
+		ActionFullscreen:  {ebiten.KeyF11},
+		ActionZoomIn:      {ebiten.KeyEqual, ebiten.KeyNumpadAdd},
+		ActionZoomOut:     {ebiten.KeyMinus, ebiten.KeyNumpadSubtract},
+		ActionScrollUp:    {ebiten.KeyPageUp},
+		ActionScrollDown:  {ebiten.KeyPageDown},
+		ActionScrollLeft:  {ebiten.KeyHome},
+		ActionScrollRight: {ebiten.KeyEnd},
+		ActionReview:      {ebiten.KeyV},
+		ActionHint:        {ebiten.KeyG},
+	}
+}
+
+// justPressed - 該動作的任一按鍵是否剛被按下
+func (bindings KeyBindings) justPressed(action InputAction) bool {
+	for _, key := range bindings[action] {
+		if inpututil.IsKeyJustPressed(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// repeated - 該動作的任一按鍵剛被按下，或按住超過 keyRepeatDelay 後每 keyRepeatInterval 個 frame 觸發一次
+func (bindings KeyBindings) repeated(action InputAction) bool {
+	for _, key := range bindings[action] {
+		if isRepeatFrame(inpututil.KeyPressDuration(key)) {
+			return true
+		}
+	}
+	return false
+}
+
+// boardCursor - 鍵盤與手把共用的游標
+type boardCursor struct {
+	Row     int
+	Col     int
+	Visible bool // 使用鍵盤或手把後才顯示，滑鼠點擊後隱藏
+}
+
+// move - 在盤面範圍內移動游標，第一次移動只會讓游標出現
+func (cursor *boardCursor) move(dRow, dCol, rows, cols int) {
+	if !cursor.Visible {
+		cursor.Visible = true
+	} else {
+		cursor.Row += dRow
+		cursor.Col += dCol
+	}
+	cursor.clamp(rows, cols)
+}
+
+// clamp - 換難度後確保游標仍在盤面內
+func (cursor *boardCursor) clamp(rows, cols int) {
+	cursor.Row = max(0, min(cursor.Row, rows-1))
+	cursor.Col = max(0, min(cursor.Col, cols-1))
+}
+
+// SetKeyBindings - 設定按鍵，未設定的動作沿用預設按鍵
+func (g *GameLayout) SetKeyBindings(bindings KeyBindings) {
+	g.keyBindings = DefaultKeyBindings()
+	for action, keys := range bindings {
+		g.keyBindings[action] = keys
+	}
+}
+
+// actionJustPressed - 鍵盤或任一手把剛觸發該動作
+func (g *GameLayout) actionJustPressed(action InputAction) bool {
+	return g.keyBindings.justPressed(action) || g.gamepadJustPressed(action)
+}
+
+// actionRepeated - 鍵盤或任一手把在該方向上需要觸發一次移動
+func (g *GameLayout) actionRepeated(action InputAction) bool {
+	return g.keyBindings.repeated(action) || g.gamepadRepeated(action)
+}
+
+// updateCursorCommands - 處理不受遊戲結束影響的動作 (游標移動、重新開始、換難度、全螢幕)，回傳 true 代表已重新開始
+func (g *GameLayout) updateCursorCommands() bool {
+	if g.actionJustPressed(ActionFullscreen) {
+		g.ToggleFullscreen()
+	}
+	switch {
+	case g.actionJustPressed(ActionRestart):
+		g.Restart()
+		return true
+	case g.remote == nil && g.actionJustPressed(ActionChangeLevel):
+		g.ChangeLevel()
+		g.Restart()
+		g.rememberLevel()
+		return true
+	}
+	before := g.cursor
+	if g.actionRepeated(ActionUp) {
+		g.cursor.move(-1, 0, g.Rows, g.Cols)
+	}
+	if g.actionRepeated(ActionDown) {
+		g.cursor.move(1, 0, g.Rows, g.Cols)
+	}
+	if g.actionRepeated(ActionLeft) {
+		g.cursor.move(0, -1, g.Rows, g.Cols)
+	}
+	if g.actionRepeated(ActionRight) {
+		g.cursor.move(0, 1, g.Rows, g.Cols)
+	}
+	if g.cursor != before {
+		g.announceCursor()
+	}
+	// 大盤面時捲動到游標所在的格子
+	if g.cursor.Visible {
+		g.scrollToCell(g.cursor.Row, g.cursor.Col)
+	}
+	return false
+}
+
+// updateCursorActions - 在游標位置執行翻開、插旗與 chord
+func (g *GameLayout) updateCursorActions() {
+	if !g.cursor.Visible {
+		if g.actionJustPressed(ActionReveal) || g.actionJustPressed(ActionFlag) || g.actionJustPressed(ActionChord) {
+			g.cursor.Visible = true
+		}
+		return
+	}
+	row, col := g.cursor.Row, g.cursor.Col
+	switch {
+	case g.actionJustPressed(ActionReveal):
+		g.ClickCoord.Row, g.ClickCoord.Col = row, col
+		// 在已翻開的數字格上按翻開等同 chord
+		if g.gameInstance.Board.GetCell(row, col).Revealed {
+			g.chordCell(row, col)
+		} else {
+			g.revealCell(row, col)
+		}
+	case g.actionJustPressed(ActionFlag):
+		g.toggleFlag(row, col)
+	case g.actionJustPressed(ActionChord):
+		g.ClickCoord.Row, g.ClickCoord.Col = row, col
+		g.chordCell(row, col)
+	}
+}
@@ -4,12 +4,23 @@ import (
 	"fmt"
 	"image"
 	"image/color"
+	"math"
+	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text/v2"
 	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/a11y"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/coop"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/daily"
 	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/leaderboard"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/settings"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/skin"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/solver"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/stats"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/storage"
 )
 
 const (
@@ -42,108 +53,310 @@ type GameLayout struct {
 	ScreenHeight int
 	ScreenWidth  int
 	level        Level
+	theme        *Theme       // 目前使用的主題
+	sprites      *spriteSheet // 目前使用的精靈圖，nil 代表使用主題的顏色與 emoji
+	colorBlind   bool         // 周圍地雷數是否使用色盲友善配色
+	shapeCues    bool         // 周圍地雷數下方是否畫出形狀提示
+	skins        []*skin.Skin // 設定畫面可以切換的 skin
+	custom       *LevelSetup  // 自訂盤面大小，nil 代表使用 level 的設定
+	remote       *coop.Client // 合作模式連線，nil 代表單機遊戲
+
+	announcer     a11y.Announcer // 螢幕閱讀器的文字輸出，nil 代表關閉
+	announcements []string       // 本 frame 累積要唸出的訊息
+
+	settings  *settings.Settings // 跨遊戲保存的偏好設定
+	sound     *soundPlayer       // 音效
+	animation animator           // 翻開、爆炸與獲勝動畫
+
+	leaderboard      *leaderboard.Leaderboard // 排行榜
+	leaderboardLevel Level                    // 排行榜目前顯示的難度
+	highlightRecord  *leaderboard.Record      // 排行榜上標示的新紀錄
+	namePrompt       *namePrompt              // 新紀錄輸入名字，nil 代表沒有在輸入
+	playerName       string                   // 上一次輸入的名字
+
+	stats        *stats.Store // 生涯統計
+	statsLevel   Level        // 統計畫面目前顯示的難度
+	statsMessage string       // 統計畫面的提示訊息 (例如匯出結果)
+
+	scenes        []Scene                // 開啟中的畫面，SceneGame 不在其中
+	sceneHandlers map[Scene]sceneHandler // 每種畫面的更新與繪製
+	transition    sceneTransition        // 切換畫面的淡出
+	press         mousePress             // 按住中的格子或按鈕
+	review        lossReview             // 失敗後的回顧
+	hint          *hint                  // 盤面上目前的提示，nil 代表沒有
+	settingsIndex int                    // 設定畫面目前選取的項目
+	menuIndex     int                    // 選單目前選取的項目
+	customIndex   int                    // 自訂盤面畫面目前選取的項目
+	customSetup   LevelSetup             // 自訂盤面畫面正在編輯的大小
+
+	mode        Mode              // 遊戲模式
+	daily       *daily.History    // 每日挑戰紀錄
+	dailyDate   time.Time         // 目前每日挑戰盤面的日期
+	dailyScored bool              // 目前的每日挑戰是否為當天計分的那一次
+	seed        int64             // 下一局使用的 seed，0 代表隨機
+	nextGame    *game.Game        // 下一局直接使用的遊戲，nil 代表依照難度產生
+	replay      bool              // 目前的盤面由指定的 seed 或盤面檔產生，地雷位置可以事先得知
+	judge       *solver.LossJudge // 目前遊戲的失敗分析，統計與失敗回顧共用
+
+	keyBindings     KeyBindings                        // 鍵盤按鍵設定
+	gamepadBindings GamepadBindings                    // 手把按鈕設定
+	gamepads        map[ebiten.GamepadID]*gamepadState // 目前連接的手把
+	cursor          boardCursor                        // 鍵盤與手把共用的游標
+
+	touches   map[ebiten.TouchID]*touchPress // 還沒放開的觸控點
+	tap       *image.Point                   // 本 frame 放開的輕觸位置
+	longPress *image.Point                   // 本 frame 剛達到長按的位置
+	touchMode TouchMode                      // 觸控輕觸時要翻開還是插旗
+
+	camera        camera        // 大盤面的可視範圍
+	boardImage    *ebiten.Image // 可視範圍內的盤面
+	minimapImage  *ebiten.Image // 小地圖，一格一個 pixel
+	minimapPixels []byte        // 小地圖的 pixel 緩衝區
+
+	cellSize int           // 格子在視窗上的大小
+	canvas   *ebiten.Image // 以 gridSize 繪製的遊戲畫面
+	scale    float64       // canvas 縮放到視窗的比例
+	offset   image.Point   // canvas 在視窗上置中的位移
 }
 
 func NewGameLayout(gameInstance *game.Game) *GameLayout {
-	return &GameLayout{gameInstance: gameInstance, ClickCoord: &Coord{},
-		Rows:       gameInstance.Board.Rows,
-		Cols:       gameInstance.Board.Cols,
-		MineCounts: gameInstance.MineCounts,
-		level:      Easy,
+	gameLayout := &GameLayout{gameInstance: gameInstance, ClickCoord: &Coord{},
+		Rows:            gameInstance.Board.Rows,
+		Cols:            gameInstance.Board.Cols,
+		MineCounts:      gameInstance.MineCounts,
+		level:           Easy,
+		leaderboard:     storage.LoadDefault("leaderboard", leaderboard.Load),
+		stats:           storage.LoadDefault("stats", stats.Load),
+		daily:           storage.LoadDefault("daily", daily.Load),
+		keyBindings:     DefaultKeyBindings(),
+		gamepadBindings: DefaultGamepadBindings(),
+		gamepads:        map[ebiten.GamepadID]*gamepadState{},
+		touches:         map[ebiten.TouchID]*touchPress{},
+		cellSize:        DefaultCellSize,
+		camera:          camera{Zoom: 1},
+		theme:           DefaultTheme,
+		skins:           loadSkins(),
+		settings:        storage.LoadDefault("settings", settings.Load),
+		sound:           newSoundPlayer(),
+		scenes:          []Scene{SceneTitle},
+	}
+	gameLayout.sceneHandlers = gameLayout.newSceneHandlers()
+	gameLayout.attachGameListeners()
+	gameLayout.applySettings()
+	// 第一局在標題畫面關閉後才開始計時
+	gameLayout.updateTimer()
+	// 設定沒有讓盤面重新開始時，公開第一局的承諾雜湊
+	if gameLayout.gameInstance == gameInstance {
+		ebiten.SetWindowTitle(gameLayout.fairnessTitle())
+		gameLayout.logCommitment()
 	}
+	return gameLayout
 }
 
 func (g *GameLayout) Update() error {
-	// 偵測　level icon 有被點擊
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
-		xPos, yPos := ebiten.CursorPosition()
-		if xPos >= ((g.ScreenWidth-1.5*gridSize)/2+buttonRectRelativePos.Min.X) &&
-			xPos <= (g.ScreenWidth)/2+buttonRectRelativePos.Max.X+0.5*gridSize &&
-			yPos >= buttonRectRelativePos.Min.Y &&
-			yPos <= buttonRectRelativePos.Max.Y+3 {
-			g.ChangeLevel()
-			g.Restart()
-		}
+	defer g.flushAnnouncements()
+	defer g.flushSounds()
+	// 手把可能在遊戲中插拔，每個 frame 重新同步
+	g.updateGamepads()
+	g.updateTouches()
+	g.updateAnimations()
+	// 合作模式下以 server 的快照為準
+	if g.remote != nil {
+		g.syncRemote()
 	}
-	// 偵測　restart icon 有被點擊
-	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		xPos, yPos := ebiten.CursorPosition()
-		if xPos >= ((g.ScreenWidth-1.5*gridSize)/2+buttonRectRelativePos.Min.X) &&
-			xPos <= (g.ScreenWidth)/2+buttonRectRelativePos.Max.X+0.5*gridSize &&
-			yPos >= gridSize+buttonRectRelativePos.Min.Y &&
-			yPos <= gridSize+buttonRectRelativePos.Max.Y+3 {
-			g.Restart()
-		}
+	g.updateTransition()
+	// 最上層的畫面接收所有輸入
+	g.sceneHandlers[g.currentScene()].update()
+	g.updateTimer()
+	return nil
+}
+
+// updateGameScene - 遊戲盤面的輸入
+func (g *GameLayout) updateGameScene() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.openMenu()
+		return
+	}
+	if g.isSettingsButtonClicked() || inpututil.IsKeyJustPressed(ebiten.KeyO) {
+		g.openSettings()
+		return
+	}
+	if g.remote == nil && (g.isPanelButtonClicked(leaderboardButtonIndex) || inpututil.IsKeyJustPressed(ebiten.KeyT)) {
+		g.openLeaderboard()
+		return
+	}
+	if g.remote == nil && (g.isPanelButtonClicked(statsButtonIndex) || inpututil.IsKeyJustPressed(ebiten.KeyI)) {
+		g.openStats()
+		return
+	}
+	// 鍵盤與手把的重新開始、換難度與游標移動
+	if g.updateCursorCommands() {
+		return
+	}
+	// 大盤面的捲動與縮放
+	g.updateCamera()
+	// 偵測模式切換
+	if g.remote == nil && (g.isModeButtonClicked() || inpututil.IsKeyJustPressed(ebiten.KeyM)) {
+		g.ChangeMode()
+		g.Restart()
+		return
+	}
+	// 偵測觸控模式切換
+	if position, clicked := g.clickPosition(); clicked && position.In(g.panelButtonRect(touchModeButtonIndex)) {
+		g.ChangeTouchMode()
+		return
+	}
+	// 偵測　level icon 有被點擊，開啟難度與模式選單
+	if position, clicked := g.clickPosition(); clicked && position.In(g.levelButtonRect()) {
+		g.openMenu()
+		return
+	}
+	// 偵測提示按鈕或 G 鍵，標示安全或風險最低的格子
+	if g.remote == nil && (g.isHintButtonClicked() || g.actionJustPressed(ActionHint)) {
+		g.RequestHint()
+		return
+	}
+	g.updateHint()
+	// 滑鼠按下時預覽，放開時翻開、chord 或重新開始
+	g.updateMousePress()
+	// 偵測　restart icon 有被輕觸
+	if g.tap != nil && g.tap.In(g.restartButtonRect()) {
+		g.Restart()
 	}
 	// 當遊戲還沒停止時，就更新經過時間
-	if !g.gameInstance.IsGameOver && !g.gameInstance.IsPlayerWin {
+	if g.remote == nil && !g.gameInstance.IsGameOver && !g.gameInstance.IsPlayerWin {
 		g.elapsedTime = g.gameInstance.GetElapsedTime()
 	}
-	// 當狀態為遊戲結束
+	// 當狀態為遊戲結束，失敗時可以回顧
+	if g.gameInstance.IsGameOver || g.gameInstance.IsPlayerWin {
+		g.updateReview()
+		return
+	}
+	// 鍵盤與手把在游標位置翻開、插旗與 chord
+	g.updateCursorActions()
 	if g.gameInstance.IsGameOver || g.gameInstance.IsPlayerWin {
-		return nil
-	}
-	// 偵測 mouse 左鍵 click 事件
-	if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
-		g.handlePositionClickEvent(func(row, col int) {
-			// 檢查是否踩到地雷
-			if g.gameInstance.Board.GetCell(row, col).IsMine {
-				g.gameInstance.IsGameOver = true
+		return
+	}
+	// 觸控輕觸與長按
+	g.updateTouchBoard()
+	if g.gameInstance.IsGameOver || g.gameInstance.IsPlayerWin {
+		return
+	}
+	// 偵測 mouse 右鍵 click 事件，左鍵按住時改為 chord
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) && !g.pressingBoard() {
+		// 標記該位置格子
+		g.handlePositionClickEvent(g.toggleFlag)
+	}
+}
+
+// revealCell - 翻開格子，合作模式下改送給 server 處理
+func (g *GameLayout) revealCell(row, col int) {
+	if g.remote != nil {
+		if !g.gameInstance.Board.GetCell(row, col).Revealed {
+			g.remote.Move(coop.ActionReveal, row, col)
+		}
+		return
+	}
+	g.gameInstance.RevealCell(row, col)
+}
+
+// chordCell - 在數字格上翻開周圍所有未插旗的格子，合作模式下依目前畫面逐格送出翻開
+func (g *GameLayout) chordCell(row, col int) {
+	if g.remote == nil {
+		g.gameInstance.Chord(row, col)
+		return
+	}
+	board := g.gameInstance.Board
+	cell := board.GetCell(row, col)
+	if !cell.Revealed || cell.AdjacenetMines == 0 {
+		return
+	}
+	flags := 0
+	var hidden []Coord
+	for neighborRow := row - 1; neighborRow <= row+1; neighborRow++ {
+		for neighborCol := col - 1; neighborCol <= col+1; neighborCol++ {
+			if neighborRow < 0 || neighborRow >= g.Rows || neighborCol < 0 || neighborCol >= g.Cols {
+				continue
 			}
-			// 執行 Flood Fill - 更新踩到之後的更新
-			g.gameInstance.Board.Reveal(row, col)
-			// 檢查是否達到勝利條件
-			if !g.gameInstance.IsGameOver {
-				g.gameInstance.IsPlayerWin = g.gameInstance.Board.CheckIsPlayerWin()
+			neighbor := board.GetCell(neighborRow, neighborCol)
+			switch {
+			case neighbor.Flagged:
+				flags++
+			case !neighbor.Revealed:
+				hidden = append(hidden, Coord{Row: neighborRow, Col: neighborCol})
 			}
-		})
+		}
 	}
-	// 偵測 mouse 右鍵 click 事件
-	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight) {
-		// 標記該位置格子
-		g.handlePositionClickEvent(func(row, col int) {
-			g.gameInstance.Board.ToggleFlag(row, col)
-		})
+	if flags != cell.AdjacenetMines {
+		return
 	}
-	return nil
+	for _, position := range hidden {
+		g.remote.Move(coop.ActionReveal, position.Row, position.Col)
+	}
+}
+
+// toggleFlag - 切換插旗，合作模式下依目前畫面決定送出插旗或取消插旗
+func (g *GameLayout) toggleFlag(row, col int) {
+	if g.remote != nil {
+		action := coop.ActionFlag
+		if g.gameInstance.Board.GetCell(row, col).Flagged {
+			action = coop.ActionUnflag
+		}
+		g.remote.Move(action, row, col)
+		return
+	}
+	g.gameInstance.ToggleFlag(row, col)
 }
 
 // drawUnRevealedCell - 畫出沒有被掀開的格子
 func (g *GameLayout) drawUnRevealedCell(screen *ebiten.Image, row, col int) {
+	if g.drawCellSprite(screen, skin.Covered, row, col) {
+		return
+	}
+	x, y := g.cellOrigin(row, col)
 	vector.DrawFilledRect(
 		screen,
-		float32(col*gridSize),
-		float32(PanelHeight+row*gridSize),
+		float32(x),
+		float32(y),
 		gridSize-1,
 		gridSize-1,
-		color.RGBA{100, 100, 100, 0xff},
+		g.theme.CoveredCell,
 		false,
 	)
+	g.drawCoveredBevel(screen, float32(x), float32(y))
 }
 
 // drawTouchCellBackground - 畫出 click 之後背景
 func (g *GameLayout) drawTouchCellBackground(screen *ebiten.Image, row, col int) {
+	if g.drawCellSprite(screen, skin.Revealed, row, col) {
+		return
+	}
+	x, y := g.cellOrigin(row, col)
 	vector.DrawFilledRect(
 		screen,
-		float32(col*gridSize),
-		float32(PanelHeight+row*gridSize),
+		float32(x),
+		float32(y),
 		gridSize-1,
 		gridSize-1,
-		color.RGBA{200, 200, 200, 0xff},
+		g.theme.RevealedCell,
 		false,
 	)
 }
 
 // drawRevealMineBackground - 畫出 click 之後 Mine 背景
 func (g *GameLayout) drawRevealMineBackground(screen *ebiten.Image, row, col int) {
-	bgColor := color.RGBA{200, 200, 0, 0xff}
-	if g.ClickCoord.Row == row && g.ClickCoord.Col == col {
-		bgColor = color.RGBA{200, 0, 0, 0xff}
+	bgColor, sprite := g.theme.RevealedMine, skin.Revealed
+	if exploded := g.explodedCell(); exploded.Row == row && exploded.Col == col {
+		bgColor, sprite = g.theme.ExplodedMine, skin.Exploded
+	}
+	if g.drawCellSprite(screen, sprite, row, col) {
+		return
 	}
+	x, y := g.cellOrigin(row, col)
 	vector.DrawFilledRect(
 		screen,
-		float32(col*gridSize),
-		float32(PanelHeight+row*gridSize),
+		float32(x),
+		float32(y),
 		gridSize-1,
 		gridSize-1,
 		bgColor,
@@ -153,51 +366,73 @@ func (g *GameLayout) drawRevealMineBackground(screen *ebiten.Image, row, col int
 
 // drawTouchCellAdjacency - 畫出 click 之後顯示出來的值
 func (g *GameLayout) drawTouchCellAdjacency(screen *ebiten.Image, row, col, value int) {
+	x, y := g.cellOrigin(row, col)
+	numberColor := g.numberColor(value)
+	textSize := 30.0
+	textYPos := y + gridSize/2
+	if g.shapeCues {
+		// 數字縮小往上移，下方留給形狀提示
+		drawNumberPips(screen, x, y, value, numberColor)
+		textSize = 22
+		textYPos = y + 12
+		if g.drawSprite(screen, skin.Number(value), x+4, y+1, gridSize-9, gridSize-9) {
+			return
+		}
+	} else if g.drawCellSprite(screen, skin.Number(value), row, col) {
+		return
+	}
 	// 繪製數字 (置中)
 	textValue := fmt.Sprintf("%d", value)
-	textXPos := col*gridSize + (gridSize)/2
-	textYPos := PanelHeight + row*gridSize + (gridSize)/2
+	textXPos := x + gridSize/2
 	textOpts := &text.DrawOptions{}
-	textOpts.ColorScale.ScaleWithColor(getTileColor(value))
+	textOpts.ColorScale.ScaleWithColor(numberColor)
 	textOpts.PrimaryAlign = text.AlignCenter
 	textOpts.SecondaryAlign = text.AlignCenter
 	textOpts.GeoM.Translate(float64(textXPos), float64(textYPos))
 	text.Draw(screen, textValue, &text.GoTextFace{
-		Source: mplusFaceSource,
-		Size:   30,
+		Source: g.theme.TextFont,
+		Size:   textSize,
 	}, textOpts)
 }
 
 // drawTouchCellMine - 畫出地雷
 func (g *GameLayout) drawTouchCellMine(screen *ebiten.Image, row, col int) {
+	if g.drawCellSprite(screen, skin.Mine, row, col) {
+		return
+	}
+	x, y := g.cellOrigin(row, col)
 	// 繪製數字 (置中)
-	textValue := "💣"
-	textXPos := col*gridSize + (gridSize)/2
-	textYPos := PanelHeight + row*gridSize + (gridSize)/2
+	textValue := g.theme.IconMine
+	textXPos := x + gridSize/2
+	textYPos := y + gridSize/2
 	textOpts := &text.DrawOptions{}
-	textOpts.ColorScale.ScaleWithColor(getTileColor(IsMine))
+	textOpts.ColorScale.ScaleWithColor(g.theme.Mine)
 	textOpts.PrimaryAlign = text.AlignCenter
 	textOpts.SecondaryAlign = text.AlignCenter
 	textOpts.GeoM.Translate(float64(textXPos), float64(textYPos))
 	text.Draw(screen, textValue, &text.GoTextFace{
-		Source: emojiFaceSource,
+		Source: g.theme.IconFont,
 		Size:   30,
 	}, textOpts)
 }
 
 // drawFlag - 標示 flag
 func (g *GameLayout) drawFlag(screen *ebiten.Image, row, col int) {
+	if g.drawCellSprite(screen, skin.Flag, row, col) {
+		return
+	}
+	x, y := g.cellOrigin(row, col)
 	// 繪製數字 (置中)
-	textValue := "🚩"
-	textXPos := col*gridSize + (gridSize)/2
-	textYPos := PanelHeight + row*gridSize + (gridSize)/2
+	textValue := g.theme.IconFlag
+	textXPos := x + gridSize/2
+	textYPos := y + gridSize/2
 	textOpts := &text.DrawOptions{}
-	textOpts.ColorScale.ScaleWithColor(getTileColor(-1))
+	textOpts.ColorScale.ScaleWithColor(g.theme.Flag)
 	textOpts.PrimaryAlign = text.AlignCenter
 	textOpts.SecondaryAlign = text.AlignCenter
 	textOpts.GeoM.Translate(float64(textXPos), float64(textYPos))
 	text.Draw(screen, textValue, &text.GoTextFace{
-		Source: emojiFaceSource,
+		Source: g.theme.IconFont,
 		Size:   30,
 	}, textOpts)
 }
@@ -205,6 +440,11 @@ func (g *GameLayout) drawFlag(screen *ebiten.Image, row, col int) {
 // drawUnRevealLogic - 繪製沒有掀開 cell 邏輯
 func (g *GameLayout) drawUnRevealLogic(screen *ebiten.Image, row, col int, cell *game.Cell) {
 	g.drawUnRevealedCell(screen, row, col)
+	// 失敗後插錯的旗子
+	if cell.Flagged && !cell.IsMine && g.gameInstance.IsGameOver && !g.animation.active() {
+		g.drawWrongFlag(screen, row, col)
+		return
+	}
 	if cell.Flagged {
 		g.drawFlag(screen, row, col)
 	}
@@ -233,20 +473,39 @@ func (g *GameLayout) drawRevealedCell(screen *ebiten.Image, row, col int, cell *
 
 // drawBoard - 畫出目前盤面狀態
 func (g *GameLayout) drawBoard(screen *ebiten.Image) {
-	for row := 0; row < g.Rows; row++ {
-		for col := 0; col < g.Cols; col++ {
+	// 只畫出可視範圍內的格子
+	minRow, minCol, maxRow, maxCol := g.visibleCells()
+	for row := minRow; row < maxRow; row++ {
+		for col := minCol; col < maxCol; col++ {
 			// 取出格子狀態
 			cell := g.gameInstance.Board.GetCell(row, col)
 
 			// 根據格子狀態，顯示對應的畫面
 			// 當格子沒有被掀開時,畫出原本的灰階
-			if !cell.Revealed {
+			switch {
+			case !cell.Revealed && !cell.Flagged && g.isPressedCell(row, col):
+				// 按住的格子顯示成凹下去的樣子
+				g.drawTouchCellBackground(screen, row, col)
+			case !cell.Revealed || g.animationHides(row, col):
 				g.drawUnRevealLogic(screen, row, col, cell)
-			} else {
+			default:
 				g.drawRevealedCell(screen, row, col, cell)
 			}
 		}
 	}
+	// 鍵盤游標
+	if g.cursor.Visible {
+		x, y := g.cellOrigin(g.cursor.Row, g.cursor.Col)
+		vector.StrokeRect(screen,
+			float32(x)+1,
+			float32(y)+1,
+			gridSize-3,
+			gridSize-3,
+			3,
+			g.theme.Cursor,
+			false,
+		)
+	}
 }
 
 // drawGamePanel - 繪製遊戲狀態面板
@@ -263,6 +522,18 @@ func (g *GameLayout) drawGamePanel(screen *ebiten.Image) {
 	g.drawElaspedTimeInfo(screen)
 	// 畫出 Level Info Button
 	g.drawLevelInfo(screen)
+	// 畫出排行榜按鈕（固定在右上方）
+	if g.remote == nil {
+		g.drawModeButton(screen)
+		g.drawPanelIconButton(screen, leaderboardButtonIndex, "🏆")
+		g.drawPanelIconButton(screen, statsButtonIndex, "📊")
+	}
+	// 觸控模式切換與設定按鈕
+	g.drawTouchModeButton(screen)
+	g.drawSettingsButton(screen)
+	if g.remote == nil {
+		g.drawHintButton(screen)
+	}
 }
 
 func (g *GameLayout) drawLevelInfo(screen *ebiten.Image) {
@@ -271,12 +542,12 @@ func (g *GameLayout) drawLevelInfo(screen *ebiten.Image) {
 	textXPos := len(textValue)
 	textYPos := PaddingY
 	textOpts := &text.DrawOptions{}
-	textOpts.ColorScale.ScaleWithColor(getTileColor(-1))
+	textOpts.ColorScale.ScaleWithColor(g.theme.PanelText)
 	textOpts.PrimaryAlign = text.AlignStart
 	textOpts.SecondaryAlign = text.AlignCenter
 	textOpts.GeoM.Translate(float64(textXPos), float64(textYPos))
 	text.Draw(screen, textValue, &text.GoTextFace{
-		Source: mplusFaceSource,
+		Source: g.theme.TextFont,
 		Size:   20,
 	}, textOpts)
 	emojiIcon := LevelIconMap[g.level]
@@ -289,7 +560,7 @@ func (g *GameLayout) drawLevelButtonWithIcon(screen *ebiten.Image, emojiIcon str
 		float32(buttonRectRelativePos.Min.Y),
 		float32(buttonRectRelativePos.Dx()+0.5*gridSize),
 		float32(buttonRectRelativePos.Dy()+4),
-		color.RGBA{120, 120, 120, 255},
+		g.theme.Button,
 		true,
 	)
 	vector.DrawFilledCircle(screen, float32(g.ScreenWidth/2), gridSize/2, 16,
@@ -300,12 +571,12 @@ func (g *GameLayout) drawLevelButtonWithIcon(screen *ebiten.Image, emojiIcon str
 	emojiXPos := (g.ScreenWidth) / 2
 	emojiYPos := PaddingY
 	emojiOpts := &text.DrawOptions{}
-	emojiOpts.ColorScale.ScaleWithColor(getTileColor(IsButtonIcon))
+	emojiOpts.ColorScale.ScaleWithColor(g.theme.ButtonIcon)
 	emojiOpts.PrimaryAlign = text.AlignCenter
 	emojiOpts.SecondaryAlign = text.AlignCenter
 	emojiOpts.GeoM.Translate(float64(emojiXPos), float64(emojiYPos))
 	text.Draw(screen, emojiValue, &text.GoTextFace{
-		Source: emojiFaceSource,
+		Source: g.theme.IconFont,
 		Size:   32,
 	}, emojiOpts)
 }
@@ -317,23 +588,26 @@ func (g *GameLayout) drawButtonWithIcon(screen *ebiten.Image, emojiIcon string)
 		float32(gridSize+buttonRectRelativePos.Min.Y),
 		float32(buttonRectRelativePos.Dx()+0.5*gridSize),
 		float32(buttonRectRelativePos.Dy()+4),
-		color.RGBA{120, 120, 120, 255},
+		g.theme.Button,
 		true,
 	)
 	vector.DrawFilledCircle(screen, float32(g.ScreenWidth/2), gridSize+gridSize/2, 16,
-		color.RGBA{180, 180, 0, 255},
+		g.theme.RestartButton,
 		true,
 	)
+	if g.drawSprite(screen, g.faceSprite(), g.ScreenWidth/2-gridSize/2, gridSize, gridSize, gridSize) {
+		return
+	}
 	emojiValue := emojiIcon
 	emojiXPos := (g.ScreenWidth) / 2
 	emojiYPos := gridSize + PaddingY
 	emojiOpts := &text.DrawOptions{}
-	emojiOpts.ColorScale.ScaleWithColor(getTileColor(IsButtonIcon))
+	emojiOpts.ColorScale.ScaleWithColor(g.theme.ButtonIcon)
 	emojiOpts.PrimaryAlign = text.AlignCenter
 	emojiOpts.SecondaryAlign = text.AlignCenter
 	emojiOpts.GeoM.Translate(float64(emojiXPos), float64(emojiYPos))
 	text.Draw(screen, emojiValue, &text.GoTextFace{
-		Source: emojiFaceSource,
+		Source: g.theme.IconFont,
 		Size:   32,
 	}, emojiOpts)
 }
@@ -344,25 +618,32 @@ func (g *GameLayout) drawRemainingFlagInfo(screen *ebiten.Image) {
 	textValue := fmt.Sprintf("%03d", g.gameInstance.Board.GetRemainingFlags())
 	textXPos := PaddingX + len(textValue)
 	textYPos := gridSize + PaddingY
+	if g.drawLEDCounter(screen, g.gameInstance.Board.GetRemainingFlags(),
+		image.Rect(textXPos, textYPos-ledDigitHeight/2, textXPos+3*ledDigitWidth, textYPos+ledDigitHeight/2)) {
+		textValue = ""
+	}
 	textOpts := &text.DrawOptions{}
-	textOpts.ColorScale.ScaleWithColor(getTileColor(-1))
+	textOpts.ColorScale.ScaleWithColor(g.theme.Counter)
 	textOpts.PrimaryAlign = text.AlignStart
 	textOpts.SecondaryAlign = text.AlignCenter
 	textOpts.GeoM.Translate(float64(textXPos), float64(textYPos))
 	text.Draw(screen, textValue, &text.GoTextFace{
-		Source: mplusFaceSource,
-		Size:   20,
+		Source: g.theme.CounterFont,
+		Size:   g.theme.CounterSize,
 	}, textOpts)
-	emojiValue := "🚩"
+	emojiValue := g.theme.IconFlag
 	emojiXPos := len(emojiValue)
 	emojiYPos := gridSize + PaddingY
+	if g.drawSprite(screen, skin.Flag, emojiXPos, emojiYPos-gridSize/2, gridSize, gridSize) {
+		return
+	}
 	emojiOpts := &text.DrawOptions{}
-	emojiOpts.ColorScale.ScaleWithColor(getTileColor(IsFlag))
+	emojiOpts.ColorScale.ScaleWithColor(g.theme.PanelIcon)
 	emojiOpts.PrimaryAlign = text.AlignStart
 	emojiOpts.SecondaryAlign = text.AlignCenter
 	emojiOpts.GeoM.Translate(float64(emojiXPos), float64(emojiYPos))
 	text.Draw(screen, emojiValue, &text.GoTextFace{
-		Source: emojiFaceSource,
+		Source: g.theme.IconFont,
 		Size:   30,
 	}, emojiOpts)
 }
@@ -373,82 +654,188 @@ func (g *GameLayout) drawElaspedTimeInfo(screen *ebiten.Image) {
 	textValue := fmt.Sprintf("%03d", g.elapsedTime)
 	textXPos := g.ScreenWidth - gridSize/2 + len(textValue)
 	textYPos := gridSize + PaddingY
+	if g.drawLEDCounter(screen, g.elapsedTime,
+		image.Rect(textXPos-3*ledDigitWidth, textYPos-ledDigitHeight/2, textXPos, textYPos+ledDigitHeight/2)) {
+		textValue = ""
+	}
 	textOpts := &text.DrawOptions{}
-	textOpts.ColorScale.ScaleWithColor(getTileColor(-1))
+	textOpts.ColorScale.ScaleWithColor(g.theme.Counter)
 	textOpts.PrimaryAlign = text.AlignEnd
 	textOpts.SecondaryAlign = text.AlignCenter
 	textOpts.GeoM.Translate(float64(textXPos), float64(textYPos))
 	text.Draw(screen, textValue, &text.GoTextFace{
-		Source: mplusFaceSource,
-		Size:   20,
+		Source: g.theme.CounterFont,
+		Size:   g.theme.CounterSize,
 	}, textOpts)
-	emojiValue := "⏰"
+	emojiValue := g.theme.IconClock
 	emojiXPos := g.ScreenWidth - 3*gridSize + len(emojiValue)
+	// 本地遊戲時讓開提示按鈕
+	if g.remote == nil {
+		emojiXPos = max(emojiXPos, g.hintButtonRect().Max.X+2)
+	}
 	emojiYPos := gridSize + PaddingY
 	emojiOpts := &text.DrawOptions{}
-	emojiOpts.ColorScale.ScaleWithColor(getTileColor(IsClock))
+	emojiOpts.ColorScale.ScaleWithColor(g.theme.PanelIcon)
 	emojiOpts.PrimaryAlign = text.AlignStart
 	emojiOpts.SecondaryAlign = text.AlignCenter
 	emojiOpts.GeoM.Translate(float64(emojiXPos), float64(emojiYPos))
 	text.Draw(screen, emojiValue, &text.GoTextFace{
-		Source: emojiFaceSource,
+		Source: g.theme.IconFont,
 		Size:   30,
 	}, emojiOpts)
 }
 
 func (g *GameLayout) Draw(screen *ebiten.Image) {
-	g.drawBoard(screen)
+	if g.canvas == nil || g.canvas.Bounds().Dx() != g.ScreenWidth || g.canvas.Bounds().Dy() != g.ScreenHeight {
+		if g.canvas != nil {
+			g.canvas.Deallocate()
+		}
+		g.canvas = ebiten.NewImage(g.ScreenWidth, g.ScreenHeight)
+	}
+	if g.transition.pending {
+		g.captureTransition()
+	}
+	g.canvas.Clear()
+	g.drawGame(g.canvas)
+	g.drawCanvas(screen)
+}
+
+// drawGame - 以 gridSize 繪製整個遊戲畫面
+func (g *GameLayout) drawGame(screen *ebiten.Image) {
+	g.drawScenes(screen)
+	g.drawTransition(screen)
+}
+
+// drawGameScene - 繪製盤面與面板
+func (g *GameLayout) drawGameScene(screen *ebiten.Image) {
+	g.drawBoardView(screen)
+	g.drawConfetti(screen)
+	g.drawMinimap(screen)
+	g.drawLongPressRings(screen)
+	g.drawReviewCaption(screen)
 	g.drawGamePanel(screen)
 }
 
+// Layout - 使用視窗的實際大小，遊戲畫面再由 drawCanvas 等比例縮放
 func (g *GameLayout) Layout(outsideWidth, outsideHeight int) (int, int) {
-	g.ScreenHeight = PanelHeight + gridSize*g.Rows
-	g.ScreenWidth = gridSize * g.Cols
-	return g.ScreenWidth, g.ScreenHeight
+	g.updateScale(outsideWidth, outsideHeight)
+	return outsideWidth, outsideHeight
 }
 
 // getColorStatus - 根據 IsGameOver 與 IsPlayerWin 來找出對 message, bgColor
 func (g *GameLayout) getColorStatus() (string, color.RGBA) {
-	bgColor := color.RGBA{100, 100, 0x10, 0xFF}
-	status := "😀"
+	bgColor := g.theme.PanelPlaying
+	status := g.theme.IconPlaying
 	if g.gameInstance.IsGameOver {
-		status = "😵"
-		bgColor = color.RGBA{150, 0, 0x10, 0xFF}
+		status = g.theme.IconLost
+		bgColor = g.theme.PanelLost
 	}
 	if g.gameInstance.IsPlayerWin {
-		status = "😎"
-		bgColor = color.RGBA{200, 200, 0, 0xFF}
+		status = g.theme.IconWon
+		bgColor = g.theme.PanelWon
+	}
+	if g.pressingBoard() {
+		status = g.theme.IconPressed
 	}
 	return status, bgColor
 }
 
+// levelButtonRect - 上方置中的難度切換按鈕範圍
+func (g *GameLayout) levelButtonRect() image.Rectangle {
+	minX := (g.ScreenWidth-1.5*gridSize)/2 + buttonRectRelativePos.Min.X
+	return image.Rect(minX, buttonRectRelativePos.Min.Y,
+		minX+buttonRectRelativePos.Dx()+0.5*gridSize, buttonRectRelativePos.Max.Y+4)
+}
+
+// restartButtonRect - 難度按鈕下方的重新開始按鈕範圍
+func (g *GameLayout) restartButtonRect() image.Rectangle {
+	return g.levelButtonRect().Add(image.Pt(0, gridSize))
+}
+
+// cellAt - 將畫面座標轉成格子位置，ok 代表落在盤面內
+func (g *GameLayout) cellAt(position image.Point) (row, col int, ok bool) {
+	// 當在面板下方、畫面內且不在小地圖上才處理
+	if position.X < 0 || position.Y < PanelHeight ||
+		position.X >= g.ScreenWidth || position.Y >= g.ScreenHeight ||
+		position.In(g.minimapRect()) {
+		return 0, 0, false
+	}
+	boardX, boardY := g.toBoard(position)
+	row = int(math.Floor(boardY / gridSize))
+	col = int(math.Floor(boardX / gridSize))
+	return row, col, row >= 0 && row < g.Rows && col >= 0 && col < g.Cols
+}
+
 // handlePositionClickEvent - 處理 click 之後把 positon 傳入
 func (g *GameLayout) handlePositionClickEvent(listenHandler func(row, col int)) {
-	xPos, yPos := ebiten.CursorPosition()
-	// 當在面板下方才處理
-	if yPos >= PanelHeight {
-		row := (yPos - PanelHeight) / gridSize
-		col := xPos / gridSize
-		g.ClickCoord.Row = row
-		g.ClickCoord.Col = col
-		if row >= 0 && row < g.Rows && col >= 0 && col < g.Cols {
-			listenHandler(row, col)
-		}
+	row, col, ok := g.cellAt(g.cursorPosition())
+	if !ok {
+		return
 	}
+	g.ClickCoord.Row = row
+	g.ClickCoord.Col = col
+	// 改用滑鼠操作時隱藏鍵盤游標，並讓游標從點擊位置繼續
+	g.cursor = boardCursor{Row: row, Col: col}
+	listenHandler(row, col)
 }
 
 // Restart - 重新建立 Game 狀態
 func (g *GameLayout) Restart() {
-	g.Rows = LevelSetupMap[g.level].Rows
-	g.Cols = LevelSetupMap[g.level].Cols
-	g.MineCounts = LevelSetupMap[g.level].MineCounts
-	g.ScreenHeight = PanelHeight + gridSize*g.Rows
-	g.ScreenWidth = gridSize * g.Cols
-	ebiten.SetWindowSize(g.ScreenWidth, g.ScreenHeight)
-	ebiten.SetWindowTitle(fmt.Sprintf("%s Mine Sweeper Grid", LevelMessage[g.level]))
-	g.gameInstance = game.NewGame(g.Rows, g.Cols, g.MineCounts)
+	if g.remote != nil {
+		g.remote.Move(coop.ActionRestart, 0, 0)
+		return
+	}
+	setup := g.levelSetup()
+	g.Rows = setup.Rows
+	g.Cols = setup.Cols
+	g.MineCounts = setup.MineCounts
+	g.ScreenWidth, g.ScreenHeight = g.canvasSize()
+	g.resetCamera()
+	g.resizeWindow()
+	g.gameInstance = g.newGameInstance()
+	g.updateTimer()
+	ebiten.SetWindowTitle(g.fairnessTitle())
+	g.attachGameListeners()
+	g.logCommitment()
+	g.cursor.clamp(g.Rows, g.Cols)
+	g.animation.reset()
+	g.review = lossReview{}
+	g.hint = nil
+	g.announceNewGame()
 }
 
 func (g *GameLayout) ChangeLevel() {
 	g.level = (g.level + 1) % 3
+	g.custom = nil
+}
+
+// SetLevel - 下一局使用指定的難度，取消自訂盤面
+func (g *GameLayout) SetLevel(level Level) {
+	g.level = level
+	g.custom = nil
+}
+
+// SetCustomBoard - 使用自訂的盤面大小與地雷數，切換難度後恢復成一般難度
+func (g *GameLayout) SetCustomBoard(setup LevelSetup) {
+	g.custom = &setup
+}
+
+// SetSeed - 下一局以固定 seed 產生盤面，之後重新開始恢復隨機
+func (g *GameLayout) SetSeed(seed int64) {
+	g.seed = seed
+}
+
+// SetNextGame - 下一局直接使用指定的遊戲 (例如從檔案讀取的盤面)，盤面大小會成為自訂盤面
+func (g *GameLayout) SetNextGame(instance *game.Game) {
+	g.mode = ModeClassic
+	g.SetCustomBoard(LevelSetup{Rows: instance.Board.Rows, Cols: instance.Board.Cols, MineCounts: instance.MineCounts})
+	g.nextGame = instance
+}
+
+// levelSetup - 目前的盤面設定，每日挑戰一律使用難度的設定
+func (g *GameLayout) levelSetup() LevelSetup {
+	if g.custom != nil && g.mode == ModeClassic {
+		return *g.custom
+	}
+	return LevelSetupMap[g.level]
 }
@@ -0,0 +1,202 @@
+package layout
+
+import (
+	"fmt"
+	"image"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/leaderboard"
+)
+
+const (
+	leaderboardButtonIndex = 0  // 排行榜按鈕在面板右上角的位置
+	maxPlayerNameLength    = 12 // 名字長度上限
+	defaultPlayerName      = "anonymous"
+)
+
+// namePrompt - 新紀錄時輸入名字的狀態
+type namePrompt struct {
+	record leaderboard.Record
+	rank   int
+	name   []rune
+}
+
+// panelButtonRect - 面板右上角第 index 個圖示按鈕 (由右往左) 的範圍
+func (g *GameLayout) panelButtonRect(index int) image.Rectangle {
+	maxX := g.ScreenWidth - 4 - index*(gridSize+4)
+	return image.Rect(maxX-gridSize, 2, maxX, 2+gridSize)
+}
+
+// isPanelButtonClicked - 是否剛點擊面板右上角第 index 個圖示按鈕
+func (g *GameLayout) isPanelButtonClicked(index int) bool {
+	position, clicked := g.clickPosition()
+	return clicked && position.In(g.panelButtonRect(index))
+}
+
+// drawPanelIconButton - 繪製面板右上角的圖示按鈕
+func (g *GameLayout) drawPanelIconButton(screen *ebiten.Image, index int, emojiIcon string) {
+	rect := g.panelButtonRect(index)
+	vector.DrawFilledRect(screen,
+		float32(rect.Min.X),
+		float32(rect.Min.Y),
+		float32(rect.Dx()),
+		float32(rect.Dy()),
+		g.theme.Button,
+		true,
+	)
+	drawTextAt(screen, emojiIcon, g.theme.IconFont, 24,
+		float64(rect.Min.X+rect.Dx()/2), float64(rect.Min.Y+rect.Dy()/2),
+		g.theme.ButtonIcon, text.AlignCenter)
+}
+
+// leaderboardListener - 遊戲勝負揭曉時寫入排行榜，每局只會觸發一次
+func (g *GameLayout) leaderboardListener() game.EventListener {
+	return func(event game.Event) {
+		if event.Type == game.EventWin || event.Type == game.EventExplode {
+			g.recordGame()
+		}
+	}
+}
+
+// recordGame - 將結束的遊戲寫入排行榜，獲勝且進入前 10 名時先詢問名字
+func (g *GameLayout) recordGame() {
+	name := g.playerName
+	if name == "" {
+		name = defaultPlayerName
+	}
+	record := leaderboard.NewRecord(name, g.level, g.gameInstance)
+	if rank := g.leaderboard.Rank(record); rank > 0 {
+		g.namePrompt = &namePrompt{record: record, rank: rank, name: []rune(g.playerName)}
+		g.pushScene(SceneNamePrompt)
+		return
+	}
+	g.saveRecord(record)
+}
+
+// saveRecord - 加入紀錄並寫回資料檔
+func (g *GameLayout) saveRecord(record leaderboard.Record) {
+	g.leaderboard.Add(record)
+	if err := g.leaderboard.Save(); err != nil {
+		log.Printf("leaderboard: %v", err)
+	}
+}
+
+// updateNamePrompt - 處理名字輸入，Enter 確認、Esc 以預設名字儲存
+func (g *GameLayout) updateNamePrompt() {
+	prompt := g.namePrompt
+	for _, r := range ebiten.AppendInputChars(nil) {
+		if len(prompt.name) < maxPlayerNameLength && utf8.ValidRune(r) {
+			prompt.name = append(prompt.name, r)
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyBackspace) && len(prompt.name) > 0 {
+		prompt.name = prompt.name[:len(prompt.name)-1]
+	}
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyEnter) || inpututil.IsKeyJustPressed(ebiten.KeyNumpadEnter) ||
+		g.tap != nil: // 觸控裝置沒有鍵盤，輕觸即確認
+		name := strings.TrimSpace(string(prompt.name))
+		if name == "" {
+			name = defaultPlayerName
+		} else {
+			g.playerName = name
+		}
+		prompt.record.Name = name
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape):
+		prompt.record.Name = defaultPlayerName
+	default:
+		return
+	}
+	g.saveRecord(prompt.record)
+	g.namePrompt = nil
+	g.popScene()
+	g.openLeaderboard()
+	g.highlightRecord = &prompt.record
+}
+
+// openLeaderboard - 顯示目前難度的排行榜
+func (g *GameLayout) openLeaderboard() {
+	g.pushScene(SceneLeaderboard)
+	g.leaderboardLevel = g.level
+	g.highlightRecord = nil
+}
+
+// updateLeaderboardScene - 左右鍵或點擊切換難度，Esc、T 或再次點擊按鈕關閉
+func (g *GameLayout) updateLeaderboardScene() {
+	levelCount := Level(len(LevelSetupMap))
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyRight):
+		g.leaderboardLevel = (g.leaderboardLevel + 1) % levelCount
+	case inpututil.IsKeyJustPressed(ebiten.KeyLeft):
+		g.leaderboardLevel = (g.leaderboardLevel + levelCount - 1) % levelCount
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape) ||
+		inpututil.IsKeyJustPressed(ebiten.KeyT) ||
+		g.isPanelButtonClicked(leaderboardButtonIndex):
+		g.popScene()
+	case g.isClicked():
+		g.leaderboardLevel = (g.leaderboardLevel + 1) % levelCount
+	}
+}
+
+// drawLeaderboardOrDailyScene - 每日挑戰模式顯示每日挑戰紀錄，其他模式顯示排行榜
+func (g *GameLayout) drawLeaderboardOrDailyScene(screen *ebiten.Image) {
+	if g.mode == ModeDaily {
+		g.drawDailyHistoryScene(screen)
+		return
+	}
+	g.drawLeaderboardScene(screen)
+}
+
+// drawLeaderboardScene - 繪製該難度前 10 名的排行榜
+func (g *GameLayout) drawLeaderboardScene(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, 0, PanelHeight, float32(g.ScreenWidth), float32(g.ScreenHeight-PanelHeight),
+		g.theme.Overlay, false)
+	centerX := float64(g.ScreenWidth) / 2
+	drawTextAt(screen, fmt.Sprintf("< %s >", LevelMessage[g.leaderboardLevel]), g.theme.TextFont, 20,
+		centerX, PanelHeight+gridSize/2, LevelColorMap[g.leaderboardLevel], text.AlignCenter)
+
+	records := g.leaderboard.Top(g.leaderboardLevel, leaderboard.TopN)
+	if len(records) == 0 {
+		drawTextAt(screen, "No records yet", g.theme.TextFont, 14,
+			centerX, PanelHeight+2*gridSize, g.theme.OverlayText, text.AlignCenter)
+	}
+	lineHeight := 20.0
+	for index, record := range records {
+		y := PanelHeight + 1.5*gridSize + float64(index)*lineHeight
+		lineColor := g.theme.OverlayText
+		if g.highlightRecord != nil && record.Date.Equal(g.highlightRecord.Date) {
+			lineColor = g.theme.Highlight
+		}
+		drawTextAt(screen, fmt.Sprintf("%2d %s", index+1, record.Name), g.theme.TextFont, 14,
+			8, y, lineColor, text.AlignStart)
+		drawTextAt(screen, fmt.Sprintf("%.3fs", record.Duration().Seconds()), g.theme.TextFont, 14,
+			float64(g.ScreenWidth)-80, y, lineColor, text.AlignEnd)
+		drawTextAt(screen, record.Date.Format("01/02"), g.theme.TextFont, 14,
+			float64(g.ScreenWidth)-8, y, lineColor, text.AlignEnd)
+	}
+	drawTextAt(screen, "←/→ level  Esc close", g.theme.TextFont, 12,
+		centerX, float64(g.ScreenHeight)-12, g.theme.OverlayText, text.AlignCenter)
+}
+
+// drawNamePrompt - 繪製新紀錄的名字輸入框
+func (g *GameLayout) drawNamePrompt(screen *ebiten.Image) {
+	prompt := g.namePrompt
+	boxHeight := float32(3 * gridSize)
+	boxY := float32(PanelHeight) + (float32(g.ScreenHeight-PanelHeight)-boxHeight)/2
+	vector.DrawFilledRect(screen, 8, boxY, float32(g.ScreenWidth-16), boxHeight,
+		withAlpha(g.theme.Overlay, 0xf0), false)
+	centerX := float64(g.ScreenWidth) / 2
+	drawTextAt(screen, fmt.Sprintf("New record! #%d  %.3fs", prompt.rank, prompt.record.Duration().Seconds()),
+		g.theme.TextFont, 16, centerX, float64(boxY)+gridSize/2, g.theme.Highlight, text.AlignCenter)
+	drawTextAt(screen, fmt.Sprintf("Name: %s_", string(prompt.name)), g.theme.TextFont, 16,
+		centerX, float64(boxY)+1.5*gridSize, g.theme.OverlayText, text.AlignCenter)
+	drawTextAt(screen, "Enter save  Esc skip", g.theme.TextFont, 12,
+		centerX, float64(boxY)+2.5*gridSize, g.theme.OverlayText, text.AlignCenter)
+}
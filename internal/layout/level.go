@@ -1,20 +1,21 @@
 package layout
 
-import "image/color"
+import (
+	"image/color"
 
-type Level int
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+)
+
+// 難度設定定義在 game package，讓不依賴 ebiten 的工具也能使用
+type Level = game.Level
 
 const (
-	Easy Level = iota
-	Medium
-	Hard
+	Easy   = game.Easy
+	Medium = game.Medium
+	Hard   = game.Hard
 )
 
-type LevelSetup struct {
-	Rows       int
-	Cols       int
-	MineCounts int
-}
+type LevelSetup = game.LevelSetup
 
 var LevelIconMap map[Level]string = map[Level]string{
 	Easy:   "🌱",
@@ -22,23 +23,7 @@ var LevelIconMap map[Level]string = map[Level]string{
 	Hard:   "💣",
 }
 
-var LevelSetupMap map[Level]LevelSetup = map[Level]LevelSetup{
-	Easy: LevelSetup{
-		9,
-		9,
-		10,
-	},
-	Medium: LevelSetup{
-		16,
-		16,
-		40,
-	},
-	Hard: LevelSetup{
-		30,
-		16,
-		99,
-	},
-}
+var LevelSetupMap = game.LevelSetupMap
 
 var LevelColorMap map[Level]color.RGBA = map[Level]color.RGBA{
 	Easy:   color.RGBA{0, 180, 0, 255},
@@ -46,8 +31,4 @@ var LevelColorMap map[Level]color.RGBA = map[Level]color.RGBA{
 	Hard:   color.RGBA{240, 0, 200, 255},
 }
 
-var LevelMessage map[Level]string = map[Level]string{
-	Easy:   "Easy",
-	Medium: "Medium",
-	Hard:   "Hard",
-}
+var LevelMessage = game.LevelMessage
@@ -0,0 +1,189 @@
+package layout
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	minCustomSize  = 2   // 自訂盤面最小的列數與欄數
+	maxCustomSize  = 200 // 自訂盤面最大的列數與欄數
+	titleBlinkRate = 40  // 標題畫面提示文字閃爍的 frame 數
+)
+
+// updateTitleScene - 任何按鍵、點擊或手把按鈕都會進入選單
+func (g *GameLayout) updateTitleScene() {
+	if g.actionJustPressed(ActionFullscreen) {
+		g.ToggleFullscreen()
+		return
+	}
+	if len(inpututil.AppendJustPressedKeys(nil)) > 0 || g.isClicked() || g.actionJustPressed(ActionReveal) {
+		g.replaceScene(SceneMenu)
+		g.menuIndex = 0
+		g.announce("menu, " + listDescription(g.menuItems(), g.menuIndex))
+	}
+}
+
+// drawTitleScene - 繪製標題畫面
+func (g *GameLayout) drawTitleScene(screen *ebiten.Image) {
+	screen.Fill(g.theme.Overlay)
+	centerX := float64(g.ScreenWidth) / 2
+	height := float64(g.ScreenHeight)
+	drawTextAt(screen, g.theme.IconMine+" "+g.theme.IconFlag, g.theme.IconFont, 40,
+		centerX, height*0.25, g.theme.OverlayText, text.AlignCenter)
+	drawTextAt(screen, "Mine Sweeper", g.theme.TextFont, 32,
+		centerX, height*0.42, g.theme.Highlight, text.AlignCenter)
+	// 提示文字閃爍
+	if (g.animation.tick/titleBlinkRate)%2 == 0 {
+		drawTextAt(screen, "Press any key or tap to start", g.theme.TextFont, 14,
+			centerX, height*0.62, g.theme.OverlayText, text.AlignCenter)
+	}
+	drawTextAt(screen, "Esc in game opens the menu", g.theme.TextFont, 12,
+		centerX, height-12, g.theme.OverlayText, text.AlignCenter)
+}
+
+// openMenu - 開啟難度與模式選單
+func (g *GameLayout) openMenu() {
+	g.pushScene(SceneMenu)
+	g.menuIndex = 0
+	g.announce("menu, " + listDescription(g.menuItems(), g.menuIndex))
+}
+
+// startLevel - 以指定難度開始新的一局並回到盤面
+func (g *GameLayout) startLevel(level Level) {
+	g.SetLevel(level)
+	g.Restart()
+	g.rememberLevel()
+	g.backToGame()
+}
+
+// levelLabel - 選單上的難度與盤面大小
+func levelLabel(level Level) string {
+	setup := LevelSetupMap[level]
+	return fmt.Sprintf("%s  %dx%d, %d mines", LevelMessage[level], setup.Rows, setup.Cols, setup.MineCounts)
+}
+
+// menuItems - 選單上的所有項目，合作模式下難度與模式由 server 決定
+func (g *GameLayout) menuItems() []listItem {
+	resume := listItem{label: "Resume", change: func(int) { g.backToGame() }}
+	settings := listItem{label: "Settings", change: func(int) { g.openSettings() }}
+	if g.remote != nil {
+		return []listItem{resume, settings}
+	}
+	items := []listItem{resume}
+	for _, level := range []Level{Easy, Medium, Hard} {
+		items = append(items, listItem{
+			label:  levelLabel(level),
+			change: func(int) { g.startLevel(level) },
+		})
+	}
+	return append(items,
+		listItem{label: "Custom board...", change: func(int) { g.openCustom() }},
+		listItem{
+			label: "Mode",
+			value: func() string { return ModeMessage[g.mode] },
+			change: func(int) {
+				g.ChangeMode()
+				g.Restart()
+			},
+		},
+		listItem{label: "Leaderboard", change: func(int) { g.openLeaderboard() }},
+		listItem{label: "Stats", change: func(int) { g.openStats() }},
+		listItem{label: "Fair play", change: func(int) { g.openFairness() }},
+		settings,
+	)
+}
+
+// updateMenuScene - 選擇項目，Esc 或再次點擊難度按鈕回到盤面
+func (g *GameLayout) updateMenuScene() {
+	if position, clicked := g.clickPosition(); inpututil.IsKeyJustPressed(ebiten.KeyEscape) ||
+		(clicked && position.In(g.levelButtonRect())) {
+		g.popScene()
+		return
+	}
+	g.updateList(g.menuItems(), &g.menuIndex)
+}
+
+// drawMenuScene - 繪製選單
+func (g *GameLayout) drawMenuScene(screen *ebiten.Image) {
+	g.drawList(screen, "Menu", g.menuItems(), g.menuIndex, "↑/↓ select  Enter choose  Esc back")
+}
+
+// openCustom - 開啟自訂盤面畫面，預設為目前的盤面大小
+func (g *GameLayout) openCustom() {
+	g.pushScene(SceneCustom)
+	g.customIndex = 0
+	g.customSetup = g.levelSetup()
+	g.announce("custom board, " + listDescription(g.customItems(), g.customIndex))
+}
+
+// clampCustomSetup - 限制自訂盤面的大小，地雷數至少留下一格安全的格子
+func (g *GameLayout) clampCustomSetup() {
+	setup := &g.customSetup
+	setup.Rows = min(max(setup.Rows, minCustomSize), maxCustomSize)
+	setup.Cols = min(max(setup.Cols, minCustomSize), maxCustomSize)
+	setup.MineCounts = min(max(setup.MineCounts, 1), setup.Rows*setup.Cols-1)
+}
+
+// customItems - 自訂盤面畫面的項目
+func (g *GameLayout) customItems() []listItem {
+	number := func(value *int) (func() string, func(int)) {
+		return func() string { return fmt.Sprintf("%d", *value) },
+			func(delta int) {
+				*value += delta
+				g.clampCustomSetup()
+			}
+	}
+	rowsValue, rowsChange := number(&g.customSetup.Rows)
+	colsValue, colsChange := number(&g.customSetup.Cols)
+	minesValue, minesChange := number(&g.customSetup.MineCounts)
+	return []listItem{
+		{label: "Rows", value: rowsValue, change: rowsChange},
+		{label: "Columns", value: colsValue, change: colsChange},
+		{label: "Mines", value: minesValue, change: minesChange},
+		{label: "Start", change: func(int) { g.startCustom() }},
+		{label: "Cancel", change: func(int) { g.popScene() }},
+	}
+}
+
+// startCustom - 以自訂盤面開始新的一局 (自訂盤面只有隨機模式)
+func (g *GameLayout) startCustom() {
+	g.mode = ModeClassic
+	g.SetCustomBoard(g.customSetup)
+	g.Restart()
+	g.rememberLevel()
+	g.backToGame()
+}
+
+// updateCustomScene - 左右調整大小與地雷數，Esc 回到選單
+func (g *GameLayout) updateCustomScene() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		g.popScene()
+		return
+	}
+	g.updateList(g.customItems(), &g.customIndex)
+}
+
+// drawCustomScene - 繪製自訂盤面畫面，下方顯示地雷密度
+func (g *GameLayout) drawCustomScene(screen *ebiten.Image) {
+	items := g.customItems()
+	g.drawList(screen, "Custom board", items, g.customIndex, "←/→ change  Enter choose  Esc back")
+	setup := g.customSetup
+	density := float64(setup.MineCounts) / float64(setup.Rows*setup.Cols)
+	rect := g.listItemRect(len(items))
+	drawTextAt(screen, fmt.Sprintf("Mine density %.1f%%", 100*density), g.theme.TextFont, 14,
+		float64(g.ScreenWidth)/2, float64(rect.Min.Y+rect.Dy()), g.theme.OverlayText, text.AlignCenter)
+	// 以長條顯示密度，超過 25% 以 ExplodedMine 顏色提醒
+	barColor := g.theme.Highlight
+	if density > 0.25 {
+		barColor = g.theme.ExplodedMine
+	}
+	barWidth := float32(g.ScreenWidth - 16)
+	barY := float32(rect.Min.Y+rect.Dy()) + 12
+	vector.StrokeRect(screen, 8, barY, barWidth, 6, 1, g.theme.OverlayText, false)
+	vector.DrawFilledRect(screen, 8, barY, barWidth*float32(min(density, 1)), 6, barColor, false)
+}
@@ -0,0 +1,93 @@
+package layout
+
+import (
+	"encoding/json"
+	"log"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/settings"
+)
+
+// saveSettings - 寫回設定檔
+func (g *GameLayout) saveSettings() {
+	if err := g.settings.Save(); err != nil {
+		log.Printf("settings: %v", err)
+	}
+}
+
+// applySettings - 套用設定檔中的外觀、輸入與上一次的難度，難度不同時重新開始
+func (g *GameLayout) applySettings() {
+	s := g.settings
+	if theme := ThemeByName(s.Theme); theme != nil {
+		g.SetTheme(theme)
+	}
+	for _, loaded := range g.skins {
+		if loaded.Name == s.Skin {
+			g.SetSkin(loaded)
+		}
+	}
+	if s.CellSize > 0 {
+		g.SetCellSize(s.CellSize)
+	}
+	g.SetColorBlind(s.ColorBlind)
+	g.SetShapeCues(s.ShapeCues)
+	for mode, message := range TouchModeMessage {
+		if message == s.TouchMode {
+			g.touchMode = mode
+		}
+	}
+	if len(s.KeyBindings) > 0 {
+		var bindings KeyBindings
+		if err := json.Unmarshal(s.KeyBindings, &bindings); err != nil {
+			log.Printf("settings: key bindings: %v", err)
+		} else {
+			g.SetKeyBindings(bindings)
+		}
+	}
+
+	if s.Level != "" {
+		level, err := game.ParseLevel(s.Level)
+		if err != nil {
+			log.Printf("settings: %v", err)
+		} else {
+			g.SetLevel(level)
+		}
+	}
+	if s.Custom != nil {
+		g.SetCustomBoard(LevelSetup{Rows: s.Custom.Rows, Cols: s.Custom.Cols, MineCounts: s.Custom.Mines})
+	}
+	if setup := g.levelSetup(); setup.Rows != g.Rows || setup.Cols != g.Cols || setup.MineCounts != g.MineCounts {
+		g.Restart()
+	}
+}
+
+// rememberLevel - 記錄玩家選擇的難度或自訂盤面，下次啟動時沿用
+//
+// 只在玩家從選單或快捷鍵換難度時呼叫，-level、-load 等啟動參數只影響這次執行，不會寫回設定檔
+func (g *GameLayout) rememberLevel() {
+	level, custom := LevelMessage[g.level], (*settings.Board)(nil)
+	if g.custom != nil && g.mode == ModeClassic {
+		custom = &settings.Board{Rows: g.custom.Rows, Cols: g.custom.Cols, Mines: g.custom.MineCounts}
+	}
+	saved := g.settings.Custom
+	sameCustom := (custom == nil && saved == nil) || (custom != nil && saved != nil && *custom == *saved)
+	if level == g.settings.Level && sameCustom {
+		return
+	}
+	g.settings.Level, g.settings.Custom = level, custom
+	g.saveSettings()
+}
+
+// rememberOptions - 設定畫面修改後記錄目前的外觀與輸入設定
+func (g *GameLayout) rememberOptions() {
+	g.settings.Theme = g.theme.Name
+	g.settings.Skin = ""
+	if s := g.Skin(); s != nil {
+		g.settings.Skin = s.Name
+	}
+	g.settings.CellSize = g.cellSize
+	g.settings.ColorBlind = g.colorBlind
+	g.settings.ShapeCues = g.shapeCues
+	g.settings.TouchMode = TouchModeMessage[g.touchMode]
+	g.saveSettings()
+}
@@ -0,0 +1,97 @@
+package layout
+
+import (
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+)
+
+// pressTarget - 滑鼠按下時按住的對象
+type pressTarget int
+
+const (
+	pressNone    pressTarget = iota // 沒有按住
+	pressCell                       // 按住盤面上的格子
+	pressRestart                    // 按住重新開始按鈕
+)
+
+// mousePress - 滑鼠按下到放開之間的狀態，放開時游標仍在同一個對象上才會執行
+type mousePress struct {
+	target   pressTarget
+	row, col int  // 按住的格子
+	chord    bool // 放開時 chord 周圍的格子
+	middle   bool // 以中鍵按下，拖曳捲動盤面時取消
+	inside   bool // 游標是否仍在按住的對象上
+}
+
+// cancelPress - 取消按住中的格子或按鈕 (例如切換畫面時)
+func (g *GameLayout) cancelPress() {
+	g.press = mousePress{}
+}
+
+// pressingBoard - 是否正按住盤面上的格子，重新開始按鈕會顯示驚訝的表情
+func (g *GameLayout) pressingBoard() bool {
+	return g.press.target == pressCell
+}
+
+// updateMousePress - 按下時預覽、放開時執行：左鍵翻開，中鍵或左右鍵同時按下 chord，按在數字格上也是 chord
+func (g *GameLayout) updateMousePress() {
+	position := g.cursorPosition()
+	left := ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft)
+	middle := ebiten.IsMouseButtonPressed(ebiten.MouseButtonMiddle)
+	right := ebiten.IsMouseButtonPressed(ebiten.MouseButtonRight)
+	playing := !g.gameInstance.IsGameOver && !g.gameInstance.IsPlayerWin
+
+	switch {
+	case inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) && position.In(g.restartButtonRect()):
+		g.press = mousePress{target: pressRestart, inside: true}
+	case playing && (inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) ||
+		inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonMiddle)):
+		if row, col, ok := g.cellAt(position); ok {
+			cell := g.gameInstance.Board.GetCell(row, col)
+			g.press = mousePress{target: pressCell, row: row, col: col, inside: true, middle: middle && !left,
+				chord: middle || right || (cell.Revealed && cell.AdjacenetMines > 0)}
+		}
+	case g.press.target == pressCell && inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonRight):
+		// 左鍵按住時再按右鍵改為 chord
+		g.press.chord = true
+	}
+
+	switch g.press.target {
+	case pressRestart:
+		g.press.inside = position.In(g.restartButtonRect())
+		if !left {
+			if g.press.inside {
+				g.Restart()
+			}
+			g.cancelPress()
+		}
+	case pressCell:
+		row, col, ok := g.cellAt(position)
+		// 中鍵拖曳捲動盤面時取消
+		g.press.inside = ok && row == g.press.row && col == g.press.col && !(g.press.middle && g.camera.dragged)
+		if left || middle {
+			return
+		}
+		press := g.press
+		g.cancelPress()
+		if !press.inside || !playing {
+			return
+		}
+		if press.chord {
+			g.handlePositionClickEvent(g.chordCell)
+		} else {
+			g.handlePositionClickEvent(g.revealCell)
+		}
+	}
+}
+
+// isPressedCell - 格子是否在按住的範圍內，chord 時包含周圍 3x3 的格子
+func (g *GameLayout) isPressedCell(row, col int) bool {
+	if g.press.target != pressCell || !g.press.inside {
+		return false
+	}
+	if g.press.chord {
+		return max(row-g.press.row, g.press.row-row) <= 1 && max(col-g.press.col, g.press.col-col) <= 1
+	}
+	return row == g.press.row && col == g.press.col
+}
@@ -0,0 +1,192 @@
+package layout
+
+import (
+	"fmt"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/skin"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/solver"
+)
+
+// reviewMark - 失敗回顧中格子在致命一擊當下的推論結果
+type reviewMark int
+
+const (
+	reviewUnknown reviewMark = iota // 無法推論或原本就已翻開
+	reviewSafe                      // 可以推論安全
+	reviewMine                      // 可以推論是地雷
+)
+
+// lossReview - 失敗後回顧致命一擊當下可以推論的格子
+type lossReview struct {
+	marks   [][]reviewMark // 依照致命一擊之前的盤面推論的結果，第一次開啟回顧時才計算
+	safe    int            // 可以推論安全的格子數
+	mines   int            // 可以推論是地雷的格子數
+	showing bool           // 是否正在顯示回顧
+}
+
+// canReview - 本地遊戲失敗後才能回顧
+func (g *GameLayout) canReview() bool {
+	return g.remote == nil && g.gameInstance.IsGameOver && g.gameInstance.BeforeLoss() != nil
+}
+
+// lossVerdict - 致命一擊的分析結果，與生涯統計共用同一次分析
+func (g *GameLayout) lossVerdict() (solver.Verdict, bool) {
+	if !g.canReview() {
+		return solver.Verdict{}, false
+	}
+	return g.judge.Verdict()
+}
+
+// verdictDescription - 致命一擊的說明文字
+func (g *GameLayout) verdictDescription() string {
+	verdict, ok := g.lossVerdict()
+	if !ok {
+		return ""
+	}
+	switch verdict.Kind {
+	case solver.LossCareless:
+		return "Careless: that cell was a deducible mine"
+	case solver.LossAvoidable:
+		return fmt.Sprintf("Avoidable guess: %.0f%% risk, %d safe known", 100*verdict.Probability, verdict.Safe)
+	}
+	// 不得不猜時，若有風險更低的格子一併提示
+	if verdict.Probability > verdict.Lowest+1e-9 {
+		return fmt.Sprintf("Forced guess (%.0f%% risk, lowest %.0f%%)", 100*verdict.Probability, 100*verdict.Lowest)
+	}
+	return fmt.Sprintf("Forced guess, unlucky (%.0f%% risk)", 100*verdict.Probability)
+}
+
+// analyzeReview - 以致命一擊前的盤面推論每個未翻開的格子
+func (g *GameLayout) analyzeReview() {
+	verdict, ok := g.lossVerdict()
+	if !ok {
+		return
+	}
+	review := &g.review
+	analysis := verdict.Analysis
+	review.marks = make([][]reviewMark, analysis.Rows)
+	for row := range review.marks {
+		review.marks[row] = make([]reviewMark, analysis.Cols)
+	}
+	review.safe, review.mines = len(analysis.Safe), len(analysis.Mines)
+	for _, cell := range analysis.Safe {
+		review.marks[cell.Row][cell.Col] = reviewSafe
+	}
+	for _, cell := range analysis.Mines {
+		review.marks[cell.Row][cell.Col] = reviewMine
+	}
+}
+
+// ToggleReview - 失敗後切換回顧，標示致命一擊當下可以推論安全與是地雷的格子
+func (g *GameLayout) ToggleReview() {
+	if !g.canReview() {
+		return
+	}
+	if g.review.marks == nil {
+		g.analyzeReview()
+	}
+	g.review.showing = !g.review.showing
+	if g.review.showing {
+		g.announce(g.verdictDescription() + ", " + g.reviewSummary())
+	} else {
+		g.announce("review hidden")
+	}
+}
+
+// reviewSummary - 回顧的說明文字
+func (g *GameLayout) reviewSummary() string {
+	return fmt.Sprintf("before the last move %d safe cells and %d mines were deducible", g.review.safe, g.review.mines)
+}
+
+// updateReview - 失敗後按 V 或點擊盤面切換回顧，跳過爆炸動畫的那次點擊不算
+func (g *GameLayout) updateReview() {
+	if !g.canReview() {
+		return
+	}
+	if g.actionJustPressed(ActionReview) {
+		g.ToggleReview()
+		return
+	}
+	if position, clicked := g.clickPosition(); clicked && !g.animation.skipped {
+		if _, _, ok := g.cellAt(position); ok {
+			g.ToggleReview()
+		}
+	}
+}
+
+// drawWrongFlag - 失敗後插錯旗子的格子畫成打叉的地雷
+func (g *GameLayout) drawWrongFlag(screen *ebiten.Image, row, col int) {
+	if g.drawCellSprite(screen, skin.WrongFlag, row, col) {
+		return
+	}
+	g.drawTouchCellMine(screen, row, col)
+	x, y := g.cellOrigin(row, col)
+	const inset = 5
+	vector.StrokeLine(screen, float32(x+inset), float32(y+inset),
+		float32(x+gridSize-1-inset), float32(y+gridSize-1-inset), 3, g.theme.WrongFlag, true)
+	vector.StrokeLine(screen, float32(x+gridSize-1-inset), float32(y+inset),
+		float32(x+inset), float32(y+gridSize-1-inset), 3, g.theme.WrongFlag, true)
+}
+
+// explodedCell - 踩到的地雷，合作模式沒有這個資訊時以最後點擊的格子代替
+func (g *GameLayout) explodedCell() game.Position {
+	if position, ok := g.gameInstance.Exploded(); ok {
+		return position
+	}
+	return game.Position{Row: g.ClickCoord.Row, Col: g.ClickCoord.Col}
+}
+
+// drawExplodedHighlight - 在踩到的地雷外圍畫出醒目的框
+func (g *GameLayout) drawExplodedHighlight(screen *ebiten.Image) {
+	if !g.gameInstance.IsGameOver {
+		return
+	}
+	exploded := g.explodedCell()
+	if g.animationHides(exploded.Row, exploded.Col) {
+		return
+	}
+	x, y := g.cellOrigin(exploded.Row, exploded.Col)
+	vector.StrokeRect(screen, float32(x)+1, float32(y)+1, gridSize-3, gridSize-3, 3, g.theme.Highlight, false)
+}
+
+// drawReview - 在致命一擊前還沒翻開的格子上標示推論結果
+func (g *GameLayout) drawReview(screen *ebiten.Image) {
+	if !g.review.showing || !g.canReview() {
+		return
+	}
+	minRow, minCol, maxRow, maxCol := g.visibleCells()
+	for row := minRow; row < min(maxRow, len(g.review.marks)); row++ {
+		for col := minCol; col < min(maxCol, len(g.review.marks[row])); col++ {
+			clr := g.theme.ReviewSafe
+			switch g.review.marks[row][col] {
+			case reviewUnknown:
+				continue
+			case reviewMine:
+				clr = g.theme.ReviewMine
+			}
+			x, y := g.cellOrigin(row, col)
+			vector.DrawFilledRect(screen, float32(x), float32(y), gridSize-1, gridSize-1, clr, false)
+		}
+	}
+}
+
+// drawReviewCaption - 失敗後在盤面下方顯示致命一擊的分析並提示回顧，回顧中顯示推論的格子數
+func (g *GameLayout) drawReviewCaption(screen *ebiten.Image) {
+	if !g.canReview() || g.animation.active() {
+		return
+	}
+	caption := "V or click the board: review"
+	if g.review.showing {
+		caption = fmt.Sprintf("Deducible before last move: %d safe, %d mines", g.review.safe, g.review.mines)
+	}
+	// 上方一行為致命一擊的分析，下方一行為回顧的說明
+	vector.DrawFilledRect(screen, 0, float32(g.ScreenHeight-36), float32(g.ScreenWidth), 36, g.theme.Overlay, false)
+	drawTextAt(screen, g.verdictDescription(), g.theme.TextFont, 12,
+		float64(g.ScreenWidth)/2, float64(g.ScreenHeight-26), g.theme.Highlight, text.AlignCenter)
+	drawTextAt(screen, caption, g.theme.TextFont, 12,
+		float64(g.ScreenWidth)/2, float64(g.ScreenHeight-10), g.theme.OverlayText, text.AlignCenter)
+}
@@ -0,0 +1,87 @@
+package layout
+
+import (
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+)
+
+// 畫面先以 gridSize 大小的格子畫在 canvas 上，再依照視窗大小等比例縮放
+const (
+	DefaultCellSize = gridSize // 預設格子在視窗上的大小
+	MinCellSize     = 12
+	MaxCellSize     = 128
+)
+
+// SetCellSize - 設定格子在視窗上的大小，視窗會跟著調整
+func (g *GameLayout) SetCellSize(size int) {
+	g.cellSize = max(MinCellSize, min(size, MaxCellSize))
+	g.resizeWindow()
+}
+
+// canvasSize - 以 gridSize 計算的遊戲畫面大小，大盤面只包含可視範圍
+func (g *GameLayout) canvasSize() (int, int) {
+	viewWidth, viewHeight := g.viewportSize()
+	return int(viewWidth), PanelHeight + int(viewHeight)
+}
+
+// WindowSize - 依照格子大小計算的視窗大小
+func (g *GameLayout) WindowSize() (int, int) {
+	width, height := g.canvasSize()
+	return width * g.cellSize / gridSize, height * g.cellSize / gridSize
+}
+
+// resizeWindow - 盤面大小或格子大小改變時調整視窗，全螢幕時不調整
+func (g *GameLayout) resizeWindow() {
+	if ebiten.IsFullscreen() {
+		return
+	}
+	ebiten.SetWindowSize(g.WindowSize())
+}
+
+// ToggleFullscreen - 切換全螢幕
+func (g *GameLayout) ToggleFullscreen() {
+	ebiten.SetFullscreen(!ebiten.IsFullscreen())
+	g.resizeWindow()
+}
+
+// updateScale - 計算 canvas 縮放到視窗的比例與置中的位移
+func (g *GameLayout) updateScale(outsideWidth, outsideHeight int) {
+	g.ScreenWidth, g.ScreenHeight = g.canvasSize()
+	g.scale = min(float64(outsideWidth)/float64(g.ScreenWidth), float64(outsideHeight)/float64(g.ScreenHeight))
+	g.offset = image.Pt(
+		(outsideWidth-int(float64(g.ScreenWidth)*g.scale))/2,
+		(outsideHeight-int(float64(g.ScreenHeight)*g.scale))/2,
+	)
+}
+
+// toCanvas - 將視窗座標轉成 canvas 座標，所有點擊判定都以 canvas 座標計算
+func (g *GameLayout) toCanvas(x, y int) image.Point {
+	if g.scale <= 0 {
+		return image.Pt(x, y)
+	}
+	// 取 floor 避免黑邊上的負數座標被捨入到 0 而誤判成第一格
+	canvasX := float64(x-g.offset.X) / g.scale
+	canvasY := float64(y-g.offset.Y) / g.scale
+	return image.Pt(int(math.Floor(canvasX)), int(math.Floor(canvasY)))
+}
+
+// cursorPosition - 滑鼠在 canvas 上的位置
+func (g *GameLayout) cursorPosition() image.Point {
+	return g.toCanvas(ebiten.CursorPosition())
+}
+
+// touchPosition - 觸控點在 canvas 上的位置
+func (g *GameLayout) touchPosition(id ebiten.TouchID) image.Point {
+	return g.toCanvas(ebiten.TouchPosition(id))
+}
+
+// drawCanvas - 將 canvas 等比例縮放並置中畫到視窗上
+func (g *GameLayout) drawCanvas(screen *ebiten.Image) {
+	options := &ebiten.DrawImageOptions{}
+	options.GeoM.Scale(g.scale, g.scale)
+	options.GeoM.Translate(float64(g.offset.X), float64(g.offset.Y))
+	options.Filter = ebiten.FilterLinear
+	screen.DrawImage(g.canvas, options)
+}
@@ -0,0 +1,282 @@
+package layout
+
+import (
+	"fmt"
+	"image"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Scene - 畫面種類，GameLayout 以堆疊管理，最上層的畫面接收輸入
+type Scene int
+
+const (
+	SceneGame        Scene = iota // 遊戲盤面，永遠在堆疊最底層
+	SceneTitle                    // 標題畫面
+	SceneMenu                     // 難度與模式選擇
+	SceneCustom                   // 自訂盤面大小
+	SceneSettings                 // 設定
+	SceneStats                    // 生涯統計
+	SceneLeaderboard              // 排行榜或每日挑戰紀錄
+	SceneNamePrompt               // 新紀錄輸入名字
+	SceneFairness                 // 盤面的承諾雜湊與公開的 seed
+)
+
+const (
+	sceneTransitionFrames = 12 // 切換畫面時舊畫面淡出的 frame 數
+	listLineHeight        = 22 // 清單畫面每個項目的高度
+	listFooterHeight      = 12 // 清單畫面下方說明文字的高度
+)
+
+// sceneHandler - 畫面的更新與繪製，opaque 代表會蓋住整個畫面，不需要畫出下層
+type sceneHandler struct {
+	update func()
+	draw   func(screen *ebiten.Image)
+	opaque bool
+}
+
+// sceneTransition - 切換畫面時保留上一個 frame 的畫面並淡出
+type sceneTransition struct {
+	pending bool          // 本 frame 有切換畫面，Draw 時擷取上一個 frame
+	frames  int           // 剩下的淡出 frame 數
+	image   *ebiten.Image // 上一個 frame 的畫面
+}
+
+// newSceneHandlers - 每種畫面的更新與繪製，NewGameLayout 建立一次後重複使用
+func (g *GameLayout) newSceneHandlers() map[Scene]sceneHandler {
+	return map[Scene]sceneHandler{
+		SceneGame:        {update: g.updateGameScene, draw: g.drawGameScene},
+		SceneTitle:       {update: g.updateTitleScene, draw: g.drawTitleScene, opaque: true},
+		SceneMenu:        {update: g.updateMenuScene, draw: g.drawMenuScene},
+		SceneCustom:      {update: g.updateCustomScene, draw: g.drawCustomScene},
+		SceneSettings:    {update: g.updateSettingsScene, draw: g.drawSettingsScene},
+		SceneStats:       {update: g.updateStatsScene, draw: g.drawStatsScene},
+		SceneLeaderboard: {update: g.updateLeaderboardScene, draw: g.drawLeaderboardOrDailyScene},
+		SceneNamePrompt:  {update: g.updateNamePrompt, draw: g.drawNamePrompt},
+		SceneFairness:    {update: g.updateFairnessScene, draw: g.drawFairnessScene},
+	}
+}
+
+// currentScene - 目前接收輸入的畫面
+func (g *GameLayout) currentScene() Scene {
+	if len(g.scenes) == 0 {
+		return SceneGame
+	}
+	return g.scenes[len(g.scenes)-1]
+}
+
+// inScene - 畫面是否在堆疊中
+func (g *GameLayout) inScene(scene Scene) bool {
+	for _, s := range g.scenes {
+		if s == scene {
+			return true
+		}
+	}
+	return scene == SceneGame
+}
+
+// pushScene - 在目前畫面上開啟新的畫面
+func (g *GameLayout) pushScene(scene Scene) {
+	g.scenes = append(g.scenes, scene)
+	g.startTransition()
+}
+
+// popScene - 關閉目前畫面，回到下一層
+func (g *GameLayout) popScene() {
+	if len(g.scenes) > 0 {
+		g.scenes = g.scenes[:len(g.scenes)-1]
+	}
+	g.startTransition()
+}
+
+// replaceScene - 以新的畫面取代目前畫面
+func (g *GameLayout) replaceScene(scene Scene) {
+	if len(g.scenes) > 0 {
+		g.scenes = g.scenes[:len(g.scenes)-1]
+	}
+	g.pushScene(scene)
+}
+
+// backToGame - 關閉所有畫面回到遊戲盤面
+func (g *GameLayout) backToGame() {
+	g.scenes = g.scenes[:0]
+	g.startTransition()
+}
+
+// updateTimer - 標題畫面、選單等任何畫面蓋在盤面上時暫停計時，回到盤面才開始計時
+//
+// 合作模式的計時以 server 為準，不會暫停
+func (g *GameLayout) updateTimer() {
+	if g.remote != nil {
+		return
+	}
+	if len(g.scenes) > 0 {
+		g.gameInstance.Pause()
+	} else {
+		g.gameInstance.Resume()
+	}
+}
+
+// startTransition - 切換畫面時取消按住中的格子，放開滑鼠時不會作用在盤面上
+func (g *GameLayout) startTransition() {
+	g.transition.pending = true
+	g.cancelPress()
+}
+
+// updateTransition - 推進淡出
+func (g *GameLayout) updateTransition() {
+	if g.transition.frames > 0 {
+		g.transition.frames--
+	}
+}
+
+// captureTransition - 在重畫之前把上一個 frame 的畫面留下來淡出，關閉動畫時直接切換
+func (g *GameLayout) captureTransition() {
+	g.transition.pending = false
+	if !g.settings.Animations || g.canvas == nil {
+		g.transition.frames = 0
+		return
+	}
+	bounds := g.canvas.Bounds()
+	if g.transition.image == nil || g.transition.image.Bounds() != bounds {
+		if g.transition.image != nil {
+			g.transition.image.Deallocate()
+		}
+		g.transition.image = ebiten.NewImage(bounds.Dx(), bounds.Dy())
+	}
+	g.transition.image.Clear()
+	g.transition.image.DrawImage(g.canvas, nil)
+	g.transition.frames = sceneTransitionFrames
+}
+
+// drawTransition - 把上一個畫面以逐漸變淡的方式疊在新畫面上
+func (g *GameLayout) drawTransition(screen *ebiten.Image) {
+	if g.transition.frames <= 0 || g.transition.image == nil || g.transition.image.Bounds() != screen.Bounds() {
+		return
+	}
+	options := &ebiten.DrawImageOptions{}
+	options.ColorScale.ScaleAlpha(float32(g.transition.frames) / sceneTransitionFrames)
+	screen.DrawImage(g.transition.image, options)
+}
+
+// drawScenes - 從最上層往下找到第一個蓋住整個畫面的畫面，再由下往上畫出
+func (g *GameLayout) drawScenes(screen *ebiten.Image) {
+	// g.scenes[from] 之前的畫面 (包含最底層的盤面) 被蓋住，-1 代表從盤面開始畫
+	from := -1
+	for index := len(g.scenes) - 1; index >= 0; index-- {
+		if g.sceneHandlers[g.scenes[index]].opaque {
+			from = index
+			break
+		}
+	}
+	if from < 0 {
+		g.sceneHandlers[SceneGame].draw(screen)
+		from = 0
+	}
+	for _, scene := range g.scenes[from:] {
+		g.sceneHandlers[scene].draw(screen)
+	}
+}
+
+// listItem - 清單畫面上的一個項目，change 的 delta 為 +1 或 -1，value 為 nil 代表執行動作的項目
+type listItem struct {
+	label  string
+	value  func() string
+	change func(delta int)
+}
+
+// listItemRect - 清單畫面第 index 列的範圍
+func (g *GameLayout) listItemRect(index int) image.Rectangle {
+	minY := PanelHeight + gridSize + index*listLineHeight
+	return image.Rect(0, minY, g.ScreenWidth, minY+listLineHeight)
+}
+
+// listVisibleItems - 清單畫面一次能顯示的項目數，保留下方說明文字的空間
+func (g *GameLayout) listVisibleItems() int {
+	return max((g.ScreenHeight-PanelHeight-gridSize-2*listFooterHeight)/listLineHeight, 1)
+}
+
+// listFirstItem - 項目超過畫面時捲動清單，讓選取的項目保持在畫面中
+func (g *GameLayout) listFirstItem(count, selected int) int {
+	visible := g.listVisibleItems()
+	return min(max(selected-visible+1, 0), max(count-visible, 0))
+}
+
+// updateList - 上下選擇項目、左右或 Enter 修改、點擊項目右半邊增加左半邊減少，回傳是否有操作
+func (g *GameLayout) updateList(items []listItem, selected *int) bool {
+	// 選取的項目或數值改變時唸出來
+	before := listDescription(items, *selected)
+	defer func() {
+		if after := listDescription(items, *selected); after != before {
+			g.announce(after)
+		}
+	}()
+	if position, clicked := g.clickPosition(); clicked {
+		first := g.listFirstItem(len(items), *selected)
+		for index := first; index < min(first+g.listVisibleItems(), len(items)); index++ {
+			if position.In(g.listItemRect(index - first)) {
+				*selected = index
+				delta := 1
+				if items[index].value != nil && position.X < g.ScreenWidth/2 {
+					delta = -1
+				}
+				items[index].change(delta)
+				return true
+			}
+		}
+	}
+	switch {
+	case g.actionRepeated(ActionUp):
+		*selected = (*selected + len(items) - 1) % len(items)
+	case g.actionRepeated(ActionDown):
+		*selected = (*selected + 1) % len(items)
+	case g.actionRepeated(ActionLeft) && items[*selected].value != nil:
+		items[*selected].change(-1)
+	case g.actionRepeated(ActionRight) && items[*selected].value != nil:
+		items[*selected].change(1)
+	case g.actionJustPressed(ActionReveal):
+		items[*selected].change(1)
+	default:
+		return false
+	}
+	return true
+}
+
+// listDescription - 清單目前選取的項目，給螢幕閱讀器使用
+func listDescription(items []listItem, selected int) string {
+	item := items[selected]
+	if item.value == nil {
+		return item.label
+	}
+	return fmt.Sprintf("%s, %s", item.label, item.value())
+}
+
+// drawList - 繪製清單畫面，選取中的項目以 Highlight 標示
+func (g *GameLayout) drawList(screen *ebiten.Image, title string, items []listItem, selected int, footer string) {
+	vector.DrawFilledRect(screen, 0, PanelHeight, float32(g.ScreenWidth), float32(g.ScreenHeight-PanelHeight),
+		g.theme.Overlay, false)
+	centerX := float64(g.ScreenWidth) / 2
+	drawTextAt(screen, title, g.theme.TextFont, 20,
+		centerX, PanelHeight+gridSize/2, g.theme.OverlayText, text.AlignCenter)
+	first := g.listFirstItem(len(items), selected)
+	for index := first; index < min(first+g.listVisibleItems(), len(items)); index++ {
+		item := items[index]
+		rect := g.listItemRect(index - first)
+		y := float64(rect.Min.Y + rect.Dy()/2)
+		lineColor := g.theme.OverlayText
+		if index == selected {
+			lineColor = g.theme.Highlight
+		}
+		if item.value == nil {
+			drawTextAt(screen, item.label, g.theme.TextFont, 16, centerX, y, lineColor, text.AlignCenter)
+			continue
+		}
+		drawTextAt(screen, item.label, g.theme.TextFont, 16, 8, y, lineColor, text.AlignStart)
+		drawTextAt(screen, fmt.Sprintf("< %s >", item.value()), g.theme.TextFont, 16,
+			float64(g.ScreenWidth)-8, y, lineColor, text.AlignEnd)
+	}
+	drawTextAt(screen, footer, g.theme.TextFont, 12,
+		centerX, float64(g.ScreenHeight)-12, g.theme.OverlayText, text.AlignCenter)
+}
@@ -0,0 +1,179 @@
+package layout
+
+import (
+	"fmt"
+	"image"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/a11y"
+)
+
+const cellSizeStep = 4 // 設定畫面每次調整格子大小的 pixel 數
+
+// settingItems - 設定畫面上的所有項目
+func (g *GameLayout) settingItems() []listItem {
+	return []listItem{
+		{
+			label: "Theme",
+			value: func() string { return g.theme.Name },
+			change: func(delta int) {
+				index := 0
+				for i, theme := range Themes {
+					if theme == g.theme {
+						index = i
+					}
+				}
+				g.SetTheme(Themes[(index+delta+len(Themes))%len(Themes)])
+			},
+		},
+		{
+			label:  "Skin",
+			value:  g.skinName,
+			change: g.changeSkin,
+		},
+		{
+			label: "Numbers",
+			value: func() string {
+				if g.colorBlind {
+					return "Colour-blind"
+				}
+				return "Theme"
+			},
+			change: func(int) { g.SetColorBlind(!g.colorBlind) },
+		},
+		{
+			label: "Shape cues",
+			value: func() string {
+				if g.shapeCues {
+					return "On"
+				}
+				return "Off"
+			},
+			change: func(int) { g.SetShapeCues(!g.shapeCues) },
+		},
+		{
+			label: "Sound",
+			value: func() string {
+				if g.settings.Muted {
+					return "Muted"
+				}
+				return "On"
+			},
+			change: func(int) { g.ToggleMute() },
+		},
+		{
+			label:  "Volume",
+			value:  func() string { return fmt.Sprintf("%d%%", int(math.Round(g.settings.Volume*100))) },
+			change: func(delta int) { g.SetVolume(g.settings.Volume + float64(delta)*volumeStep) },
+		},
+		{
+			label: "Animations",
+			value: func() string {
+				if g.settings.Animations {
+					return "On"
+				}
+				return "Off"
+			},
+			change: func(int) { g.SetAnimations(!g.settings.Animations) },
+		},
+		{
+			label:  "Cell size",
+			value:  func() string { return fmt.Sprintf("%dpx", g.cellSize) },
+			change: func(delta int) { g.SetCellSize(g.cellSize + delta*cellSizeStep) },
+		},
+		{
+			label: "Fullscreen",
+			value: func() string {
+				if ebiten.IsFullscreen() {
+					return "On"
+				}
+				return "Off"
+			},
+			change: func(int) { g.ToggleFullscreen() },
+		},
+		{
+			label: "Announcements",
+			value: func() string {
+				if g.announcer != nil {
+					return "On"
+				}
+				return "Off"
+			},
+			change: func(int) {
+				if g.announcer != nil {
+					g.SetAnnouncer(nil)
+					return
+				}
+				g.SetAnnouncer(a11y.Default())
+				g.announce("announcements on")
+			},
+		},
+		{
+			label:  "Touch tap",
+			value:  func() string { return TouchModeMessage[g.touchMode] },
+			change: func(int) { g.ChangeTouchMode() },
+		},
+		{
+			label:  "Hint penalty",
+			value:  func() string { return fmt.Sprintf("%ds", g.settings.HintPenalty) },
+			change: func(delta int) { g.SetHintPenalty(g.settings.HintPenalty + delta*hintPenaltyStep) },
+		},
+	}
+}
+
+// settingsButtonRect - 設定按鈕位於重新開始按鈕左側
+func (g *GameLayout) settingsButtonRect() image.Rectangle {
+	restart := g.restartButtonRect()
+	return image.Rect(restart.Min.X-4-gridSize, gridSize+2, restart.Min.X-4, 2*gridSize+2)
+}
+
+// isSettingsButtonClicked - 是否剛點擊設定按鈕
+func (g *GameLayout) isSettingsButtonClicked() bool {
+	position, clicked := g.clickPosition()
+	return clicked && position.In(g.settingsButtonRect())
+}
+
+// openSettings - 顯示設定畫面
+func (g *GameLayout) openSettings() {
+	g.pushScene(SceneSettings)
+	g.settingsIndex = 0
+	g.announce("settings, " + listDescription(g.settingItems(), g.settingsIndex))
+}
+
+// updateSettingsScene - 上下選擇項目、左右或 Enter 修改、點擊項目修改，Esc、O 或再次點擊按鈕關閉
+func (g *GameLayout) updateSettingsScene() {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) ||
+		inpututil.IsKeyJustPressed(ebiten.KeyO) ||
+		g.isSettingsButtonClicked() {
+		g.popScene()
+		return
+	}
+	if g.updateList(g.settingItems(), &g.settingsIndex) {
+		g.rememberOptions()
+	}
+}
+
+// drawSettingsButton - 繪製設定按鈕
+func (g *GameLayout) drawSettingsButton(screen *ebiten.Image) {
+	rect := g.settingsButtonRect()
+	vector.DrawFilledRect(screen,
+		float32(rect.Min.X),
+		float32(rect.Min.Y),
+		float32(rect.Dx()),
+		float32(rect.Dy()),
+		g.theme.Button,
+		true,
+	)
+	drawTextAt(screen, "⚙", g.theme.IconFont, 24,
+		float64(rect.Min.X+rect.Dx()/2), float64(rect.Min.Y+rect.Dy()/2),
+		g.theme.ButtonIcon, text.AlignCenter)
+}
+
+// drawSettingsScene - 繪製設定項目
+func (g *GameLayout) drawSettingsScene(screen *ebiten.Image) {
+	g.drawList(screen, "Settings", g.settingItems(), g.settingsIndex, "↑/↓ select  ←/→ change  Esc close")
+}
@@ -0,0 +1,139 @@
+package layout
+
+import (
+	"fmt"
+	"image"
+	"log"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/skin"
+)
+
+const (
+	ledDigitWidth  = 13 // 面板上 LED 數字的寬度
+	ledDigitHeight = 23 // 面板上 LED 數字的高度
+)
+
+// spriteSheet - 把 skin 的精靈圖轉成 ebiten 圖片並切好每個圖塊
+type spriteSheet struct {
+	skin    *skin.Skin
+	sprites map[string]*ebiten.Image
+}
+
+// newSpriteSheet - 建立 skin 對應的 spriteSheet
+func newSpriteSheet(s *skin.Skin) *spriteSheet {
+	sheet := ebiten.NewImageFromImage(s.Image)
+	origin := s.Image.Bounds().Min
+	sprites := make(map[string]*ebiten.Image, len(s.Sprites))
+	for name, rect := range s.Sprites {
+		sprites[name] = sheet.SubImage(rect.Sub(origin)).(*ebiten.Image)
+	}
+	return &spriteSheet{skin: s, sprites: sprites}
+}
+
+// loadSkins - 讀取內建的 skin，失敗時設定畫面只能選擇不使用 skin
+func loadSkins() []*skin.Skin {
+	defaultSkin, err := skin.Default()
+	if err != nil {
+		log.Printf("skin: %v", err)
+		return nil
+	}
+	return []*skin.Skin{defaultSkin}
+}
+
+// SetSkin - 使用精靈圖繪製盤面與面板，nil 代表使用主題的顏色與 emoji
+func (g *GameLayout) SetSkin(s *skin.Skin) {
+	if s == nil {
+		g.sprites = nil
+		return
+	}
+	if g.sprites != nil && g.sprites.skin == s {
+		return
+	}
+	g.sprites = newSpriteSheet(s)
+	// 同名的 skin 只保留最後讀取的那一個
+	for index, loaded := range g.skins {
+		if loaded.Name == s.Name {
+			g.skins[index] = s
+			return
+		}
+	}
+	g.skins = append(g.skins, s)
+}
+
+// Skin - 目前使用的 skin，nil 代表沒有使用精靈圖
+func (g *GameLayout) Skin() *skin.Skin {
+	if g.sprites == nil {
+		return nil
+	}
+	return g.sprites.skin
+}
+
+// skinName - 設定畫面顯示的 skin 名稱
+func (g *GameLayout) skinName() string {
+	if g.sprites == nil {
+		return "None"
+	}
+	return g.sprites.skin.Name
+}
+
+// changeSkin - 在不使用 skin 與已讀取的 skin 之間切換
+func (g *GameLayout) changeSkin(delta int) {
+	choices := append([]*skin.Skin{nil}, g.skins...)
+	index := 0
+	for i, choice := range choices {
+		if choice == g.Skin() {
+			index = i
+		}
+	}
+	g.SetSkin(choices[(index+delta+len(choices))%len(choices)])
+}
+
+// drawSprite - 把圖塊縮放到 (x, y) 開始 width x height 的範圍，沒有使用 skin 時回傳 false
+func (g *GameLayout) drawSprite(screen *ebiten.Image, name string, x, y, width, height int) bool {
+	if g.sprites == nil {
+		return false
+	}
+	sprite, ok := g.sprites.sprites[name]
+	if !ok {
+		return false
+	}
+	bounds := sprite.Bounds()
+	opts := &ebiten.DrawImageOptions{}
+	opts.GeoM.Scale(float64(width)/float64(bounds.Dx()), float64(height)/float64(bounds.Dy()))
+	opts.GeoM.Translate(float64(x), float64(y))
+	screen.DrawImage(sprite, opts)
+	return true
+}
+
+// drawCellSprite - 在格子上畫出圖塊
+func (g *GameLayout) drawCellSprite(screen *ebiten.Image, name string, row, col int) bool {
+	x, y := g.cellOrigin(row, col)
+	return g.drawSprite(screen, name, x, y, gridSize-1, gridSize-1)
+}
+
+// drawLEDCounter - 以 LED 數字圖塊畫出三位數，rect 為計數器範圍
+func (g *GameLayout) drawLEDCounter(screen *ebiten.Image, value int, rect image.Rectangle) bool {
+	if g.sprites == nil {
+		return false
+	}
+	value = min(max(value, 0), 999)
+	for index, digit := range fmt.Sprintf("%03d", value) {
+		g.drawSprite(screen, skin.Digit(int(digit-'0')),
+			rect.Min.X+index*ledDigitWidth, rect.Min.Y, ledDigitWidth, ledDigitHeight)
+	}
+	return true
+}
+
+// faceSprite - 依照遊戲狀態選擇重新開始按鈕上的表情
+func (g *GameLayout) faceSprite() string {
+	switch {
+	case g.gameInstance.IsPlayerWin:
+		return skin.FaceWon
+	case g.gameInstance.IsGameOver:
+		return skin.FaceLost
+	case g.pressingBoard():
+		return skin.FacePressed
+	}
+	return skin.FacePlaying
+}
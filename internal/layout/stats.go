@@ -0,0 +1,152 @@
+package layout
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/solver"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/stats"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/storage"
+)
+
+const statsButtonIndex = 1 // 統計按鈕在面板右上角的位置
+
+// attachGameListeners - 將統計等模組掛到目前的遊戲事件上，每次建立新遊戲都需要重新掛上
+func (g *GameLayout) attachGameListeners() {
+	g.judge = solver.NewLossJudge(g.gameInstance)
+	g.gameInstance.AddListener(g.announceListener())
+	g.gameInstance.AddListener(g.soundListener())
+	g.gameInstance.AddListener(g.animationListener())
+	g.gameInstance.AddListener(g.fairnessListener())
+	// 每日挑戰的盤面大家都相同，另外記錄，不列入生涯統計與排行榜
+	if g.mode == ModeDaily {
+		g.gameInstance.AddListener(g.dailyListener())
+		return
+	}
+	// 自訂盤面無法與其他紀錄比較，指定 seed 或盤面檔可以事先知道地雷位置，都不列入生涯統計與排行榜
+	if g.custom != nil || g.replay {
+		return
+	}
+	g.gameInstance.AddListener(g.stats.Listener(g.level, g.gameInstance, g.judge, func(stats.GameResult) {
+		if err := g.stats.Save(); err != nil {
+			log.Printf("stats: %v", err)
+		}
+	}))
+	g.gameInstance.AddListener(g.leaderboardListener())
+}
+
+// openStats - 顯示目前難度的統計
+func (g *GameLayout) openStats() {
+	g.pushScene(SceneStats)
+	g.statsLevel = g.level
+	g.statsMessage = ""
+}
+
+// updateStatsScene - 左右鍵切換難度、E 匯出 CSV/JSON，Esc、I 或再次點擊按鈕關閉
+func (g *GameLayout) updateStatsScene() {
+	levelCount := Level(len(LevelSetupMap))
+	switch {
+	case inpututil.IsKeyJustPressed(ebiten.KeyRight):
+		g.statsLevel = (g.statsLevel + 1) % levelCount
+	case inpututil.IsKeyJustPressed(ebiten.KeyLeft):
+		g.statsLevel = (g.statsLevel + levelCount - 1) % levelCount
+	case inpututil.IsKeyJustPressed(ebiten.KeyE):
+		g.statsMessage = g.exportStats()
+	case inpututil.IsKeyJustPressed(ebiten.KeyEscape) ||
+		inpututil.IsKeyJustPressed(ebiten.KeyI) ||
+		g.isPanelButtonClicked(statsButtonIndex):
+		g.popScene()
+	case g.isClicked():
+		g.statsLevel = (g.statsLevel + 1) % levelCount
+	}
+}
+
+// exportStats - 匯出 stats.csv 與 stats-export.json (桌面版寫到資料目錄，網頁版以瀏覽器下載)，回傳顯示給玩家的訊息
+func (g *GameLayout) exportStats() string {
+	location := ""
+	for _, export := range []struct {
+		name  string
+		write func(io.Writer) error
+	}{
+		{name: "stats.csv", write: g.stats.ExportCSV},
+		{name: "stats-export.json", write: g.stats.ExportJSON},
+	} {
+		var err error
+		if location, err = storage.Export(export.name, export.write); err != nil {
+			log.Printf("stats: %v", err)
+			return "Export failed"
+		}
+	}
+	log.Printf("stats exported to %s", location)
+	return "Exported to " + location
+}
+
+// formatDuration - 以秒顯示時間
+func formatDuration(duration time.Duration) string {
+	return fmt.Sprintf("%.1fs", duration.Seconds())
+}
+
+// drawStatsScene - 繪製該難度的生涯統計與獲勝時間分佈
+func (g *GameLayout) drawStatsScene(screen *ebiten.Image) {
+	vector.DrawFilledRect(screen, 0, PanelHeight, float32(g.ScreenWidth), float32(g.ScreenHeight-PanelHeight),
+		g.theme.Overlay, false)
+	centerX := float64(g.ScreenWidth) / 2
+	drawTextAt(screen, fmt.Sprintf("< %s >", LevelMessage[g.statsLevel]), g.theme.TextFont, 20,
+		centerX, PanelHeight+gridSize/2, LevelColorMap[g.statsLevel], text.AlignCenter)
+
+	summary := g.stats.Summary(g.statsLevel)
+	lines := [][2]string{
+		{"Played", fmt.Sprintf("%d", summary.Played)},
+		{"Won", fmt.Sprintf("%d (%.1f%%)", summary.Won, 100*summary.WinRate)},
+		{"Streak", fmt.Sprintf("%d (best %d)", summary.CurrentStreak, summary.LongestStreak)},
+		{"Assisted", fmt.Sprintf("%d", summary.Assisted)},
+		{"Losses", fmt.Sprintf("%d unlucky, %d careless", summary.Unlucky, summary.Careless)},
+		{"Average", formatDuration(summary.AverageTime)},
+		{"Median", formatDuration(summary.MedianTime)},
+	}
+	lineHeight := 18.0
+	for index, line := range lines {
+		y := PanelHeight + gridSize + 4 + float64(index)*lineHeight
+		drawTextAt(screen, line[0], g.theme.TextFont, 14, 8, y, g.theme.OverlayText, text.AlignStart)
+		drawTextAt(screen, line[1], g.theme.TextFont, 14, float64(g.ScreenWidth)-8, y, g.theme.OverlayText, text.AlignEnd)
+	}
+
+	// 獲勝時間分佈圖
+	chartTop := PanelHeight + gridSize + float64(len(lines))*lineHeight + 8
+	chartBottom := float64(g.ScreenHeight) - 40
+	if len(summary.Histogram) > 0 && chartBottom > chartTop {
+		maxCount := 1
+		for _, bucket := range summary.Histogram {
+			maxCount = max(maxCount, bucket.Count)
+		}
+		barWidth := float64(g.ScreenWidth-16) / float64(len(summary.Histogram))
+		for index, bucket := range summary.Histogram {
+			barHeight := (chartBottom - chartTop) * float64(bucket.Count) / float64(maxCount)
+			vector.DrawFilledRect(screen,
+				float32(8+float64(index)*barWidth+1),
+				float32(chartBottom-barHeight),
+				float32(barWidth-2),
+				float32(barHeight),
+				LevelColorMap[g.statsLevel],
+				false,
+			)
+		}
+		last := summary.Histogram[len(summary.Histogram)-1]
+		drawTextAt(screen, "0s", g.theme.TextFont, 10, 8, chartBottom+8, g.theme.OverlayText, text.AlignStart)
+		drawTextAt(screen, formatDuration(last.To), g.theme.TextFont, 10,
+			float64(g.ScreenWidth)-8, chartBottom+8, g.theme.OverlayText, text.AlignEnd)
+	}
+
+	footer := "←/→ level  E export  Esc close"
+	if g.statsMessage != "" {
+		footer = g.statsMessage
+	}
+	drawTextAt(screen, footer, g.theme.TextFont, 12,
+		centerX, float64(g.ScreenHeight)-12, g.theme.OverlayText, text.AlignCenter)
+}
@@ -0,0 +1,376 @@
+package layout
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+// Theme - 畫面使用的所有顏色、字型與圖示
+type Theme struct {
+	Name string
+
+	TextFont    *text.GoTextFaceSource // 一般文字與格子上的數字
+	IconFont    *text.GoTextFaceSource // emoji 圖示
+	CounterFont *text.GoTextFaceSource // 面板上的旗子數與經過時間
+	CounterSize float64
+
+	CoveredCell       color.RGBA    // 未翻開的格子
+	CoveredHighlight  color.RGBA    // 未翻開格子左上的立體邊框，Alpha 為 0 時不畫
+	CoveredShadow     color.RGBA    // 未翻開格子右下的立體邊框，Alpha 為 0 時不畫
+	RevealedCell      color.RGBA    // 已翻開的格子
+	RevealedMine      color.RGBA    // 遊戲結束後顯示的地雷背景
+	ExplodedMine      color.RGBA    // 踩到的地雷背景
+	Numbers           [9]color.RGBA // 周圍地雷數 1~8 的顏色，index 0 不使用
+	ColorBlindNumbers [9]color.RGBA // 色盲友善的周圍地雷數顏色
+	Mine              color.RGBA    // 地雷圖示
+	Flag              color.RGBA    // 盤面上的旗子圖示
+	Cursor            color.RGBA    // 鍵盤游標與長按進度環
+	WrongFlag         color.RGBA    // 失敗後插錯旗子的格子上的叉叉
+	ReviewSafe        color.RGBA    // 失敗回顧中可以推論安全的格子
+	ReviewMine        color.RGBA    // 失敗回顧中可以推論是地雷的格子
+
+	PanelPlaying  color.RGBA // 遊戲進行中的面板背景
+	PanelLost     color.RGBA // 失敗時的面板背景
+	PanelWon      color.RGBA // 獲勝時的面板背景
+	PanelText     color.RGBA // 面板文字
+	PanelIcon     color.RGBA // 面板上的旗子與時鐘圖示
+	Counter       color.RGBA // 面板上的旗子數與經過時間
+	Button        color.RGBA // 面板按鈕背景
+	RestartButton color.RGBA // 重新開始按鈕的圓形底色
+	ButtonIcon    color.RGBA // 按鈕上的圖示
+
+	Overlay     color.RGBA // 排行榜、統計與設定畫面的背景
+	OverlayText color.RGBA // 上述畫面的文字
+	Highlight   color.RGBA // 新紀錄、選取的項目與小地圖的可視範圍
+
+	IconMine    string
+	IconFlag    string
+	IconClock   string
+	IconPlaying string // 遊戲進行中的表情
+	IconPressed string // 按住格子時的表情
+	IconLost    string // 失敗時的表情
+	IconWon     string // 獲勝時的表情
+}
+
+// classicNumbers - Windows 踩地雷的數字顏色：藍、綠、紅、深藍、褐紅、藍綠、黑、灰
+var classicNumbers = [9]color.RGBA{
+	1: {0, 0, 0xff, 0xff},
+	2: {0, 128, 0, 0xff},
+	3: {0xff, 0, 0, 0xff},
+	4: {0, 0, 128, 0xff},
+	5: {128, 0, 0, 0xff},
+	6: {0, 128, 128, 0xff},
+	7: {0, 0, 0, 0xff},
+	8: {128, 128, 128, 0xff},
+}
+
+// darkNumbers - 深色背景上調亮的數字顏色
+var darkNumbers = [9]color.RGBA{
+	1: {100, 160, 0xff, 0xff},
+	2: {110, 200, 110, 0xff},
+	3: {0xff, 100, 100, 0xff},
+	4: {170, 140, 0xff, 0xff},
+	5: {220, 130, 90, 0xff},
+	6: {80, 210, 210, 0xff},
+	7: {230, 230, 235, 0xff},
+	8: {150, 150, 160, 0xff},
+}
+
+// highContrastNumbers - 黑色背景上飽和度最高的數字顏色
+var highContrastNumbers = [9]color.RGBA{
+	1: {0, 0xff, 0xff, 0xff},
+	2: {0, 0xff, 0, 0xff},
+	3: {0xff, 80, 80, 0xff},
+	4: {0xff, 0xff, 0, 0xff},
+	5: {0xff, 0, 0xff, 0xff},
+	6: {0xff, 160, 0, 0xff},
+	7: {0xff, 0xff, 0xff, 0xff},
+	8: {180, 180, 180, 0xff},
+}
+
+// colorBlindLightNumbers - 淺色背景使用的 Okabe-Ito 色盲友善配色
+var colorBlindLightNumbers = [9]color.RGBA{
+	1: {0, 114, 178, 0xff},
+	2: {0, 158, 115, 0xff},
+	3: {213, 94, 0, 0xff},
+	4: {204, 121, 167, 0xff},
+	5: {230, 159, 0, 0xff},
+	6: {86, 180, 233, 0xff},
+	7: {0, 0, 0, 0xff},
+	8: {100, 100, 100, 0xff},
+}
+
+// colorBlindDarkNumbers - 深色背景使用的 Okabe-Ito 色盲友善配色
+var colorBlindDarkNumbers = [9]color.RGBA{
+	1: {86, 180, 233, 0xff},
+	2: {0, 190, 140, 0xff},
+	3: {240, 120, 40, 0xff},
+	4: {204, 121, 167, 0xff},
+	5: {230, 159, 0, 0xff},
+	6: {240, 228, 66, 0xff},
+	7: {0xff, 0xff, 0xff, 0xff},
+	8: {150, 150, 150, 0xff},
+}
+
+// DefaultTheme - 原本的配色
+var DefaultTheme = &Theme{
+	Name:        "Default",
+	TextFont:    mplusFaceSource,
+	IconFont:    emojiFaceSource,
+	CounterFont: mplusFaceSource,
+	CounterSize: 20,
+
+	CoveredCell:       color.RGBA{100, 100, 100, 0xff},
+	RevealedCell:      color.RGBA{200, 200, 200, 0xff},
+	RevealedMine:      color.RGBA{200, 200, 0, 0xff},
+	ExplodedMine:      color.RGBA{200, 0, 0, 0xff},
+	Numbers:           classicNumbers,
+	ColorBlindNumbers: colorBlindLightNumbers,
+	Mine:              color.RGBA{0, 0, 0, 0xff},
+	Flag:              color.RGBA{0xf9, 0xf6, 0xf2, 0xff},
+	Cursor:            color.RGBA{0, 120, 255, 0xff},
+	WrongFlag:         color.RGBA{220, 0, 0, 0xff},
+	ReviewSafe:        color.RGBA{0, 110, 45, 0x90},
+	ReviewMine:        color.RGBA{0x90, 34, 34, 0x90},
+
+	PanelPlaying:  color.RGBA{100, 100, 0x10, 0xff},
+	PanelLost:     color.RGBA{150, 0, 0x10, 0xff},
+	PanelWon:      color.RGBA{200, 200, 0, 0xff},
+	PanelText:     color.RGBA{0xf9, 0xf6, 0xf2, 0xff},
+	PanelIcon:     color.RGBA{0xff, 0, 0, 0xff},
+	Counter:       color.RGBA{0xf9, 0xf6, 0xf2, 0xff},
+	Button:        color.RGBA{120, 120, 120, 0xff},
+	RestartButton: color.RGBA{180, 180, 0, 0xff},
+	ButtonIcon:    color.RGBA{0, 0, 0, 0xff},
+
+	Overlay:     color.RGBA{30, 30, 40, 0xff},
+	OverlayText: color.RGBA{0xf9, 0xf6, 0xf2, 0xff},
+	Highlight:   color.RGBA{0xff, 0xd7, 0, 0xff},
+
+	IconMine:    "💣",
+	IconFlag:    "🚩",
+	IconClock:   "⏰",
+	IconPlaying: "😀",
+	IconPressed: "😮",
+	IconLost:    "😵",
+	IconWon:     "😎",
+}
+
+// ClassicTheme - 仿 Windows 踩地雷的灰色立體格子與紅色計數器
+var ClassicTheme = &Theme{
+	Name:        "Classic",
+	TextFont:    mplusFaceSource,
+	IconFont:    emojiFaceSource,
+	CounterFont: pressStart2PFaceSource,
+	CounterSize: 14,
+
+	CoveredCell:       color.RGBA{192, 192, 192, 0xff},
+	CoveredHighlight:  color.RGBA{0xff, 0xff, 0xff, 0xff},
+	CoveredShadow:     color.RGBA{128, 128, 128, 0xff},
+	RevealedCell:      color.RGBA{180, 180, 180, 0xff},
+	RevealedMine:      color.RGBA{180, 180, 180, 0xff},
+	ExplodedMine:      color.RGBA{0xff, 0, 0, 0xff},
+	Numbers:           classicNumbers,
+	ColorBlindNumbers: colorBlindLightNumbers,
+	Mine:              color.RGBA{0, 0, 0, 0xff},
+	Flag:              color.RGBA{0xff, 0, 0, 0xff},
+	Cursor:            color.RGBA{0, 0, 0xff, 0xff},
+	WrongFlag:         color.RGBA{0xff, 0, 0, 0xff},
+	ReviewSafe:        color.RGBA{0, 110, 45, 0x90},
+	ReviewMine:        color.RGBA{0x90, 34, 34, 0x90},
+
+	PanelPlaying:  color.RGBA{192, 192, 192, 0xff},
+	PanelLost:     color.RGBA{192, 192, 192, 0xff},
+	PanelWon:      color.RGBA{192, 192, 192, 0xff},
+	PanelText:     color.RGBA{0, 0, 0, 0xff},
+	PanelIcon:     color.RGBA{0xff, 0, 0, 0xff},
+	Counter:       color.RGBA{0xff, 0, 0, 0xff},
+	Button:        color.RGBA{160, 160, 160, 0xff},
+	RestartButton: color.RGBA{0xff, 0xff, 0, 0xff},
+	ButtonIcon:    color.RGBA{0, 0, 0, 0xff},
+
+	Overlay:     color.RGBA{0, 0, 128, 0xff},
+	OverlayText: color.RGBA{0xff, 0xff, 0xff, 0xff},
+	Highlight:   color.RGBA{0xff, 0xff, 0, 0xff},
+
+	IconMine:    "💣",
+	IconFlag:    "🚩",
+	IconClock:   "⏰",
+	IconPlaying: "🙂",
+	IconPressed: "😮",
+	IconLost:    "😵",
+	IconWon:     "😎",
+}
+
+// DarkTheme - 深色背景，適合在暗的環境下遊玩
+var DarkTheme = &Theme{
+	Name:        "Dark",
+	TextFont:    mplusFaceSource,
+	IconFont:    emojiFaceSource,
+	CounterFont: mplusFaceSource,
+	CounterSize: 20,
+
+	CoveredCell:       color.RGBA{58, 63, 75, 0xff},
+	RevealedCell:      color.RGBA{30, 33, 40, 0xff},
+	RevealedMine:      color.RGBA{110, 100, 30, 0xff},
+	ExplodedMine:      color.RGBA{170, 30, 40, 0xff},
+	Numbers:           darkNumbers,
+	ColorBlindNumbers: colorBlindDarkNumbers,
+	Mine:              color.RGBA{235, 235, 240, 0xff},
+	Flag:              color.RGBA{0xff, 90, 90, 0xff},
+	Cursor:            color.RGBA{80, 160, 0xff, 0xff},
+	WrongFlag:         color.RGBA{0xff, 90, 90, 0xff},
+	ReviewSafe:        color.RGBA{34, 124, 68, 0x90},
+	ReviewMine:        color.RGBA{0x90, 50, 50, 0x90},
+
+	PanelPlaying:  color.RGBA{24, 26, 32, 0xff},
+	PanelLost:     color.RGBA{100, 20, 30, 0xff},
+	PanelWon:      color.RGBA{30, 90, 50, 0xff},
+	PanelText:     color.RGBA{230, 230, 235, 0xff},
+	PanelIcon:     color.RGBA{0xff, 90, 90, 0xff},
+	Counter:       color.RGBA{230, 230, 235, 0xff},
+	Button:        color.RGBA{70, 75, 90, 0xff},
+	RestartButton: color.RGBA{200, 170, 40, 0xff},
+	ButtonIcon:    color.RGBA{0, 0, 0, 0xff},
+
+	Overlay:     color.RGBA{16, 18, 24, 0xff},
+	OverlayText: color.RGBA{230, 230, 235, 0xff},
+	Highlight:   color.RGBA{0xff, 0xd7, 0, 0xff},
+
+	IconMine:    "💣",
+	IconFlag:    "🚩",
+	IconClock:   "⏰",
+	IconPlaying: "😀",
+	IconPressed: "😮",
+	IconLost:    "😵",
+	IconWon:     "😎",
+}
+
+// HighContrastTheme - 黑白高對比，未翻開與已翻開的格子差異最大
+var HighContrastTheme = &Theme{
+	Name:        "High contrast",
+	TextFont:    mplusFaceSource,
+	IconFont:    emojiFaceSource,
+	CounterFont: mplusFaceSource,
+	CounterSize: 20,
+
+	CoveredCell:       color.RGBA{0xff, 0xff, 0xff, 0xff},
+	RevealedCell:      color.RGBA{0, 0, 0, 0xff},
+	RevealedMine:      color.RGBA{0xff, 0xff, 0, 0xff},
+	ExplodedMine:      color.RGBA{0xff, 0, 0, 0xff},
+	Numbers:           highContrastNumbers,
+	ColorBlindNumbers: colorBlindDarkNumbers,
+	Mine:              color.RGBA{0, 0, 0, 0xff},
+	Flag:              color.RGBA{0xd0, 0, 0, 0xff},
+	Cursor:            color.RGBA{0, 0xff, 0xff, 0xff},
+	WrongFlag:         color.RGBA{0xff, 0, 0, 0xff},
+	ReviewSafe:        color.RGBA{0, 0xa0, 0, 0xa0},
+	ReviewMine:        color.RGBA{0xa0, 0, 0xa0, 0xa0},
+
+	PanelPlaying:  color.RGBA{0, 0, 0, 0xff},
+	PanelLost:     color.RGBA{160, 0, 0, 0xff},
+	PanelWon:      color.RGBA{0, 110, 0, 0xff},
+	PanelText:     color.RGBA{0xff, 0xff, 0xff, 0xff},
+	PanelIcon:     color.RGBA{0xff, 0xff, 0, 0xff},
+	Counter:       color.RGBA{0xff, 0xff, 0xff, 0xff},
+	Button:        color.RGBA{0xff, 0xff, 0xff, 0xff},
+	RestartButton: color.RGBA{0xff, 0xff, 0, 0xff},
+	ButtonIcon:    color.RGBA{0, 0, 0, 0xff},
+
+	Overlay:     color.RGBA{0, 0, 0, 0xff},
+	OverlayText: color.RGBA{0xff, 0xff, 0xff, 0xff},
+	Highlight:   color.RGBA{0xff, 0xff, 0, 0xff},
+
+	IconMine:    "💣",
+	IconFlag:    "🚩",
+	IconClock:   "⏰",
+	IconPlaying: "😀",
+	IconPressed: "😮",
+	IconLost:    "😵",
+	IconWon:     "😎",
+}
+
+// Themes - 內建的主題，設定畫面依照這個順序切換
+var Themes = []*Theme{DefaultTheme, ClassicTheme, DarkTheme, HighContrastTheme}
+
+// ThemeByName - 依照名稱找出內建主題，找不到時回傳 nil
+func ThemeByName(name string) *Theme {
+	for _, theme := range Themes {
+		if theme.Name == name {
+			return theme
+		}
+	}
+	return nil
+}
+
+// withAlpha - 換掉顏色的透明度
+func withAlpha(clr color.RGBA, alpha uint8) color.RGBA {
+	clr.A = alpha
+	return clr
+}
+
+// SetTheme - 切換主題，nil 代表恢復原本的配色
+func (g *GameLayout) SetTheme(theme *Theme) {
+	if theme == nil {
+		theme = DefaultTheme
+	}
+	g.theme = theme
+}
+
+// Theme - 目前使用的主題
+func (g *GameLayout) Theme() *Theme {
+	return g.theme
+}
+
+// SetColorBlind - 周圍地雷數改用主題的色盲友善配色
+func (g *GameLayout) SetColorBlind(enabled bool) {
+	g.colorBlind = enabled
+}
+
+// SetShapeCues - 在數字下方畫出與數字相同個數的點，不需要分辨顏色也能快速辨識
+func (g *GameLayout) SetShapeCues(enabled bool) {
+	g.shapeCues = enabled
+}
+
+// numberColor - 周圍地雷數 value 的顏色
+func (g *GameLayout) numberColor(value int) color.RGBA {
+	if value < 1 || value > 8 {
+		return g.theme.OverlayText
+	}
+	if g.colorBlind {
+		return g.theme.ColorBlindNumbers[value]
+	}
+	return g.theme.Numbers[value]
+}
+
+// drawNumberPips - 在格子下方畫出 value 個點，每列最多 4 個
+func drawNumberPips(screen *ebiten.Image, x, y, value int, clr color.RGBA) {
+	const (
+		radius  = 1.6
+		spacing = 5
+	)
+	for index := 0; index < value; index++ {
+		row, col := index/4, index%4
+		count := min(value-row*4, 4)
+		centerX := float32(x) + gridSize/2 + (float32(col)-float32(count-1)/2)*spacing
+		centerY := float32(y) + gridSize - 8 + float32(row)*4
+		vector.DrawFilledCircle(screen, centerX, centerY, radius, clr, true)
+	}
+}
+
+// drawCoveredBevel - 在未翻開的格子上畫出立體邊框
+func (g *GameLayout) drawCoveredBevel(screen *ebiten.Image, x, y float32) {
+	const width = 3
+	size := float32(gridSize - 1)
+	if g.theme.CoveredHighlight.A > 0 {
+		vector.DrawFilledRect(screen, x, y, size, width, g.theme.CoveredHighlight, false)
+		vector.DrawFilledRect(screen, x, y, width, size, g.theme.CoveredHighlight, false)
+	}
+	if g.theme.CoveredShadow.A > 0 {
+		vector.DrawFilledRect(screen, x, y+size-width, size, width, g.theme.CoveredShadow, false)
+		vector.DrawFilledRect(screen, x+size-width, y, width, size, g.theme.CoveredShadow, false)
+	}
+}
@@ -0,0 +1,184 @@
+package layout
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text/v2"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+)
+
+const (
+	touchModeButtonIndex = 2  // 觸控模式按鈕在面板右上角的位置
+	longPressFrames      = 30 // 按住多少個 frame 視為長按 (約 0.5 秒)
+	longPressRingDelay   = 6  // 按住多少個 frame 之後才開始顯示長按進度環，避免輕觸時閃爍
+	touchSlop            = 10 // 手指移動超過幾個 pixel 就不再視為點擊
+)
+
+// TouchMode - 觸控輕觸格子時執行的動作，長按則執行另一個
+type TouchMode int
+
+const (
+	TouchModeDig  TouchMode = iota // 輕觸翻開，長按插旗
+	TouchModeFlag                  // 輕觸插旗，長按翻開
+)
+
+var TouchModeIconMap map[TouchMode]string = map[TouchMode]string{
+	TouchModeDig:  "⛏",
+	TouchModeFlag: "🚩",
+}
+
+var TouchModeMessage map[TouchMode]string = map[TouchMode]string{
+	TouchModeDig:  "Dig",
+	TouchModeFlag: "Flag",
+}
+
+var (
+	whiteImage    = ebiten.NewImage(3, 3)
+	whiteSubImage = whiteImage.SubImage(image.Rect(1, 1, 2, 2)).(*ebiten.Image) // 畫三角形用的純色貼圖
+)
+
+func init() {
+	whiteImage.Fill(color.White)
+}
+
+// touchPress - 一個還沒放開的觸控點
+type touchPress struct {
+	start  image.Point // 按下的位置
+	last   image.Point // 最後的位置，放開後 ebiten 就拿不到座標
+	drag   image.Point // 這個 frame 移動的距離，用來捲動大盤面
+	frames int         // 按住的 frame 數
+	moved  bool        // 移動超過 touchSlop，不再視為點擊或長按
+	held   bool        // 已經觸發長按
+}
+
+// updateTouches - 追蹤所有觸控點，將放開的輕觸記在 g.tap，剛達到長按的位置記在 g.longPress
+func (g *GameLayout) updateTouches() {
+	g.tap, g.longPress = nil, nil
+	for _, id := range inpututil.AppendJustPressedTouchIDs(nil) {
+		position := g.touchPosition(id)
+		g.touches[id] = &touchPress{start: position, last: position}
+	}
+	for id, press := range g.touches {
+		if inpututil.IsTouchJustReleased(id) {
+			if !press.moved && !press.held {
+				position := press.last
+				g.tap = &position
+			}
+			delete(g.touches, id)
+			continue
+		}
+		position := g.touchPosition(id)
+		press.drag = position.Sub(press.last)
+		press.last = position
+		press.frames = inpututil.TouchPressDuration(id)
+		delta := press.last.Sub(press.start)
+		if delta.X*delta.X+delta.Y*delta.Y > touchSlop*touchSlop {
+			press.moved = true
+		}
+		if !press.moved && !press.held && press.frames >= longPressFrames {
+			press.held = true
+			position := press.start
+			g.longPress = &position
+		}
+	}
+}
+
+// clickPosition - 本 frame 滑鼠左鍵按下或觸控輕觸的位置
+func (g *GameLayout) clickPosition() (image.Point, bool) {
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		return g.cursorPosition(), true
+	}
+	if g.tap != nil {
+		return *g.tap, true
+	}
+	return image.Point{}, false
+}
+
+// isClicked - 本 frame 是否有滑鼠左鍵點擊或觸控輕觸
+func (g *GameLayout) isClicked() bool {
+	_, clicked := g.clickPosition()
+	return clicked
+}
+
+// ChangeTouchMode - 切換觸控模式
+func (g *GameLayout) ChangeTouchMode() {
+	g.touchMode = (g.touchMode + 1) % TouchMode(len(TouchModeIconMap))
+}
+
+// updateTouchBoard - 輕觸與長按盤面：數字格一律 chord，其他格子依照觸控模式翻開或插旗
+func (g *GameLayout) updateTouchBoard() {
+	if g.tap != nil {
+		g.touchCell(*g.tap, g.touchMode == TouchModeFlag)
+	}
+	if g.longPress != nil {
+		g.touchCell(*g.longPress, g.touchMode == TouchModeDig)
+	}
+}
+
+// touchCell - 觸控某個位置的格子，flag 代表要插旗而不是翻開
+func (g *GameLayout) touchCell(position image.Point, flag bool) {
+	row, col, ok := g.cellAt(position)
+	if !ok {
+		return
+	}
+	g.cursor = boardCursor{Row: row, Col: col}
+	g.ClickCoord.Row, g.ClickCoord.Col = row, col
+	switch {
+	case g.gameInstance.Board.GetCell(row, col).Revealed:
+		g.chordCell(row, col)
+	case flag:
+		g.toggleFlag(row, col)
+	default:
+		g.revealCell(row, col)
+	}
+}
+
+// drawTouchModeButton - 繪製觸控模式切換按鈕
+func (g *GameLayout) drawTouchModeButton(screen *ebiten.Image) {
+	rect := g.panelButtonRect(touchModeButtonIndex)
+	vector.DrawFilledRect(screen,
+		float32(rect.Min.X),
+		float32(rect.Min.Y),
+		float32(rect.Dx()),
+		float32(rect.Dy()),
+		g.theme.Button,
+		true,
+	)
+	drawTextAt(screen, TouchModeIconMap[g.touchMode], g.theme.IconFont, 24,
+		float64(rect.Min.X+rect.Dx()/2), float64(rect.Min.Y+rect.Dy()/2),
+		g.theme.ButtonIcon, text.AlignCenter)
+}
+
+// drawLongPressRings - 在按住的格子上畫出長按進度環
+func (g *GameLayout) drawLongPressRings(screen *ebiten.Image) {
+	for _, press := range g.touches {
+		if press.moved || press.held || press.frames < longPressRingDelay {
+			continue
+		}
+		if _, _, ok := g.cellAt(press.start); !ok {
+			continue
+		}
+		centerX, centerY := float32(press.start.X), float32(press.start.Y)
+		radius := float32(gridSize) * 0.75
+		vector.StrokeCircle(screen, centerX, centerY, radius, 4, withAlpha(g.theme.Overlay, 0x60), true)
+
+		progress := float32(press.frames) / longPressFrames
+		var path vector.Path
+		startAngle := float32(-math.Pi / 2)
+		path.Arc(centerX, centerY, radius, startAngle, startAngle+2*math.Pi*progress, vector.Clockwise)
+		vertices, indices := path.AppendVerticesAndIndicesForStroke(nil, nil, &vector.StrokeOptions{Width: 4})
+		ringColor := g.theme.Cursor
+		for index := range vertices {
+			vertices[index].SrcX, vertices[index].SrcY = 1, 1
+			vertices[index].ColorR = float32(ringColor.R) / 0xff
+			vertices[index].ColorG = float32(ringColor.G) / 0xff
+			vertices[index].ColorB = float32(ringColor.B) / 0xff
+			vertices[index].ColorA = 1
+		}
+		screen.DrawTriangles(vertices, indices, whiteSubImage, &ebiten.DrawTrianglesOptions{AntiAlias: true})
+	}
+}
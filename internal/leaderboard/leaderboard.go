@@ -0,0 +1,126 @@
+package leaderboard
+
+import (
+	"sort"
+	"time"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/storage"
+)
+
+// FileName - 排行榜資料檔名稱
+const FileName = "leaderboard.json"
+
+// TopN - 每個難度顯示的名次數
+const TopN = 10
+
+// Record - 一場結束的遊戲紀錄
+type Record struct {
+	Name         string     `json:"name"`
+	Won          bool       `json:"won"`
+	Level        game.Level `json:"level"`
+	Rows         int        `json:"rows"`
+	Cols         int        `json:"cols"`
+	Mines        int        `json:"mines"`
+	Milliseconds int64      `json:"ms"`
+	ThreeBV      int        `json:"3bv"`
+	Date         time.Time  `json:"date"`
+	Seed         int64      `json:"seed"`
+	Hints        int        `json:"hints,omitempty"` // 使用提示的次數，有輔助的對局不參加排名
+}
+
+// Duration - 完成時間
+func (r Record) Duration() time.Duration {
+	return time.Duration(r.Milliseconds) * time.Millisecond
+}
+
+// Leaderboard - 所有結束的遊戲紀錄，依難度分組以獲勝紀錄排名
+type Leaderboard struct {
+	file    storage.File
+	Records []Record `json:"records"`
+}
+
+// Load - 從 store 讀取排行榜，資料不存在時回傳空的排行榜
+func Load(store storage.Store) (*Leaderboard, error) {
+	board := &Leaderboard{}
+	file, err := storage.Open(store, FileName, board)
+	board.file = file
+	return board, err
+}
+
+// Save - 寫回資料檔
+func (l *Leaderboard) Save() error {
+	return l.file.Save(l)
+}
+
+// matches - 紀錄是否為該難度標準盤面上沒有使用提示的獲勝紀錄
+func matches(record Record, level game.Level) bool {
+	setup, ok := game.LevelSetupMap[level]
+	return ok && record.Won && record.Hints == 0 && record.Level == level &&
+		record.Rows == setup.Rows && record.Cols == setup.Cols && record.Mines == setup.MineCounts
+}
+
+// less - 時間短者優先，同時間以較早達成者優先
+func less(a, b Record) bool {
+	if a.Milliseconds != b.Milliseconds {
+		return a.Milliseconds < b.Milliseconds
+	}
+	return a.Date.Before(b.Date)
+}
+
+// Top - 取出該難度最快的 n 筆獲勝紀錄
+func (l *Leaderboard) Top(level game.Level, n int) []Record {
+	var records []Record
+	for _, record := range l.Records {
+		if matches(record, level) {
+			records = append(records, record)
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return less(records[i], records[j])
+	})
+	if len(records) > n {
+		records = records[:n]
+	}
+	return records
+}
+
+// Rank - 若加入 record 後的名次 (從 1 開始)，落敗、使用過提示或沒有進入前 TopN 時回傳 0
+func (l *Leaderboard) Rank(record Record) int {
+	if !matches(record, record.Level) {
+		return 0
+	}
+	rank := 1
+	for _, existing := range l.Top(record.Level, TopN) {
+		// 同時間的新紀錄排在既有紀錄之後
+		if existing.Milliseconds <= record.Milliseconds {
+			rank++
+		}
+	}
+	if rank > TopN {
+		return 0
+	}
+	return rank
+}
+
+// Add - 加入一筆紀錄
+func (l *Leaderboard) Add(record Record) {
+	l.Records = append(l.Records, record)
+}
+
+// NewRecord - 由已結束的遊戲建立紀錄
+func NewRecord(name string, level game.Level, gameInstance *game.Game) Record {
+	return Record{
+		Name:         name,
+		Won:          gameInstance.IsPlayerWin,
+		Level:        level,
+		Rows:         gameInstance.Board.Rows,
+		Cols:         gameInstance.Board.Cols,
+		Mines:        gameInstance.MineCounts,
+		Milliseconds: gameInstance.GetElapsedDuration().Milliseconds(),
+		ThreeBV:      gameInstance.Board.ThreeBV(),
+		Date:         time.Now(),
+		Seed:         gameInstance.Seed,
+		Hints:        gameInstance.Hints,
+	}
+}
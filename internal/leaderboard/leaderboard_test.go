@@ -0,0 +1,104 @@
+package leaderboard
+
+import (
+	"testing"
+	"time"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func easyRecord(name string, ms int64) Record {
+	setup := game.LevelSetupMap[game.Easy]
+	return Record{
+		Name:         name,
+		Won:          true,
+		Level:        game.Easy,
+		Rows:         setup.Rows,
+		Cols:         setup.Cols,
+		Mines:        setup.MineCounts,
+		Milliseconds: ms,
+		Date:         time.Date(2026, 1, 1, 0, 0, int(ms), 0, time.UTC),
+	}
+}
+
+func TestTopAndRank(t *testing.T) {
+	board := &Leaderboard{}
+	for i := 0; i < TopN; i++ {
+		board.Add(easyRecord("player", int64(1000*(i+1))))
+	}
+	// 不同難度或非標準盤面不會出現在排行中
+	other := easyRecord("medium", 1)
+	other.Level = game.Medium
+	board.Add(other)
+	custom := easyRecord("custom", 1)
+	custom.Mines = 3
+	board.Add(custom)
+	// 落敗的對局有紀錄但不參加排名
+	lost := easyRecord("lost", 1)
+	lost.Won = false
+	board.Add(lost)
+	// 使用提示的獲勝也不參加排名
+	assisted := easyRecord("assisted", 1)
+	assisted.Hints = 1
+	board.Add(assisted)
+
+	top := board.Top(game.Easy, TopN)
+	require.Len(t, top, TopN)
+	assert.Equal(t, int64(1000), top[0].Milliseconds)
+	assert.Equal(t, int64(10000), top[TopN-1].Milliseconds)
+
+	tests := []struct {
+		name   string
+		record Record
+		want   int
+	}{
+		{name: "new best", record: easyRecord("fast", 500), want: 1},
+		{name: "tie goes after existing record", record: easyRecord("tie", 2000), want: 3},
+		{name: "too slow", record: easyRecord("slow", 20000), want: 0},
+		{name: "custom board never ranks", record: custom, want: 0},
+		{name: "loss never ranks", record: lost, want: 0},
+		{name: "assisted win never ranks", record: assisted, want: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, board.Rank(tt.record))
+		})
+	}
+}
+
+func TestSaveAndLoad(t *testing.T) {
+	store := storage.Dir(t.TempDir())
+	board, err := Load(store)
+	require.NoError(t, err)
+	assert.Empty(t, board.Records)
+
+	board.Add(easyRecord("alice", 1234))
+	lost := easyRecord("bob", 99)
+	lost.Won = false
+	board.Add(lost)
+	// 使用提示的獲勝也不參加排名
+	assisted := easyRecord("assisted", 1)
+	assisted.Hints = 1
+	board.Add(assisted)
+	require.NoError(t, board.Save())
+
+	loaded, err := Load(store)
+	require.NoError(t, err)
+	assert.Equal(t, board.Records, loaded.Records)
+	assert.Len(t, loaded.Top(game.Easy, TopN), 1)
+}
+
+func TestNewRecord(t *testing.T) {
+	gameInstance := game.NewGameWithSeed(3, 3, 0, 9)
+	gameInstance.RevealCell(0, 0)
+	require.True(t, gameInstance.IsPlayerWin)
+	record := NewRecord("alice", game.Easy, gameInstance)
+	assert.True(t, record.Won)
+	assert.Zero(t, record.Hints)
+	assert.Equal(t, int64(9), record.Seed)
+	assert.Equal(t, 1, record.ThreeBV)
+	assert.Equal(t, 3, record.Rows)
+}
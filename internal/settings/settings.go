@@ -0,0 +1,68 @@
+package settings
+
+import (
+	"encoding/json"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/storage"
+)
+
+// FileName - 設定檔名稱
+const FileName = "settings.json"
+
+// DefaultVolume - 預設音量
+const DefaultVolume = 0.8
+
+// DefaultHintPenalty - 每次使用提示預設加上的秒數
+const DefaultHintPenalty = 10
+
+// Board - 上一次遊玩的自訂盤面大小
+type Board struct {
+	Rows  int `json:"rows"`
+	Cols  int `json:"cols"`
+	Mines int `json:"mines"`
+}
+
+// Settings - 跨遊戲保存的玩家偏好，空字串與 0 代表使用程式的預設值
+type Settings struct {
+	file        storage.File
+	Level       string          `json:"level,omitempty"`       // 上一次遊玩的難度名稱
+	Custom      *Board          `json:"custom,omitempty"`      // 上一次遊玩的自訂盤面，nil 代表使用難度
+	Theme       string          `json:"theme,omitempty"`       // 主題名稱
+	Skin        string          `json:"skin,omitempty"`        // 精靈圖名稱，空字串代表不使用
+	CellSize    int             `json:"cellSize,omitempty"`    // 格子在視窗上的大小
+	ColorBlind  bool            `json:"colorBlind"`            // 周圍地雷數使用色盲友善配色
+	ShapeCues   bool            `json:"shapeCues"`             // 數字下方畫出點數
+	Volume      float64         `json:"volume"`                // 音效音量 0~1
+	Muted       bool            `json:"muted"`                 // 是否靜音
+	Animations  bool            `json:"animations"`            // 是否播放翻開、爆炸與獲勝動畫
+	HintPenalty int             `json:"hintPenalty"`           // 每次使用提示加上的秒數
+	TouchMode   string          `json:"touchMode,omitempty"`   // 觸控輕觸的動作
+	KeyBindings json.RawMessage `json:"keyBindings,omitempty"` // 鍵盤按鍵設定，格式與 layout.KeyBindings 相同
+}
+
+// Default - 預設的設定
+func Default() *Settings {
+	return &Settings{Volume: DefaultVolume, Animations: true, HintPenalty: DefaultHintPenalty}
+}
+
+// Load - 從 store 讀取設定，資料不存在或讀取失敗時回傳預設值
+func Load(store storage.Store) (*Settings, error) {
+	settings := Default()
+	file, err := storage.Open(store, FileName, settings)
+	if err != nil {
+		return Default(), err
+	}
+	settings.file = file
+	settings.Volume = min(max(settings.Volume, 0), 1)
+	settings.HintPenalty = max(settings.HintPenalty, 0)
+	if settings.Custom != nil && (settings.Custom.Rows <= 0 || settings.Custom.Cols <= 0 ||
+		settings.Custom.Mines <= 0 || settings.Custom.Mines >= settings.Custom.Rows*settings.Custom.Cols) {
+		settings.Custom = nil
+	}
+	return settings, nil
+}
+
+// Save - 寫回設定檔
+func (s *Settings) Save() error {
+	return s.file.Save(s)
+}
@@ -0,0 +1,82 @@
+package settings
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveAndLoad(t *testing.T) {
+	store := storage.Dir(t.TempDir())
+	settings, err := Load(store)
+	require.NoError(t, err)
+	assert.Equal(t, DefaultVolume, settings.Volume)
+	assert.False(t, settings.Muted)
+	assert.True(t, settings.Animations)
+	assert.Equal(t, DefaultHintPenalty, settings.HintPenalty)
+
+	settings.Volume = 0.3
+	settings.Muted = true
+	settings.Animations = false
+	settings.Level = "Hard"
+	settings.HintPenalty = 0
+	settings.Custom = &Board{Rows: 20, Cols: 30, Mines: 100}
+	settings.Theme = "Dark"
+	settings.CellSize = 48
+	settings.TouchMode = "Flag"
+	settings.KeyBindings = []byte(`{"flag":["Q"]}`)
+	require.NoError(t, settings.Save())
+
+	loaded, err := Load(store)
+	require.NoError(t, err)
+	assert.Equal(t, 0.3, loaded.Volume)
+	assert.True(t, loaded.Muted)
+	assert.False(t, loaded.Animations)
+	assert.Equal(t, "Hard", loaded.Level)
+	assert.Equal(t, 0, loaded.HintPenalty)
+	assert.Equal(t, &Board{Rows: 20, Cols: 30, Mines: 100}, loaded.Custom)
+	assert.Equal(t, "Dark", loaded.Theme)
+	assert.Equal(t, 48, loaded.CellSize)
+	assert.Equal(t, "Flag", loaded.TouchMode)
+	assert.JSONEq(t, `{"flag":["Q"]}`, string(loaded.KeyBindings))
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		wantVolume float64
+		wantCustom *Board
+		wantErr    bool
+	}{
+		{name: "missing volume keeps default", content: `{"muted": true}`, wantVolume: DefaultVolume},
+		{name: "volume is clamped", content: `{"volume": 3}`, wantVolume: 1},
+		{name: "negative volume is clamped", content: `{"volume": -1}`, wantVolume: 0},
+		{
+			name:       "custom board is kept",
+			content:    `{"custom": {"rows": 10, "cols": 12, "mines": 20}}`,
+			wantVolume: DefaultVolume,
+			wantCustom: &Board{Rows: 10, Cols: 12, Mines: 20},
+		},
+		{name: "custom board without safe cell is dropped", content: `{"custom": {"rows": 2, "cols": 2, "mines": 4}}`, wantVolume: DefaultVolume},
+		{name: "broken file", content: `{`, wantVolume: DefaultVolume, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(dir, FileName), []byte(tt.content), 0o644))
+			settings, err := Load(storage.Dir(dir))
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantVolume, settings.Volume)
+			assert.Equal(t, tt.wantCustom, settings.Custom)
+		})
+	}
+}
@@ -0,0 +1,298 @@
+//go:build ignore
+
+// gen.go - 以程式繪製內建的經典 skin，產生 default.png 與 default.json
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"log"
+	"math"
+	"os"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/skin"
+)
+
+const (
+	tileSize    = 32 // 格子與表情圖塊的大小
+	digitWidth  = 16 // LED 數字的寬
+	digitHeight = 28 // LED 數字的高
+)
+
+var (
+	black     = color.RGBA{0, 0, 0, 0xff}
+	white     = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	lightGray = color.RGBA{192, 192, 192, 0xff}
+	darkGray  = color.RGBA{128, 128, 128, 0xff}
+	red       = color.RGBA{0xff, 0, 0, 0xff}
+	dimRed    = color.RGBA{70, 0, 0, 0xff}
+	yellow    = color.RGBA{0xff, 0xff, 0, 0xff}
+
+	// 經典的數字顏色
+	numberColors = [9]color.RGBA{
+		1: {0, 0, 0xff, 0xff},
+		2: {0, 128, 0, 0xff},
+		3: {0xff, 0, 0, 0xff},
+		4: {0, 0, 128, 0xff},
+		5: {128, 0, 0, 0xff},
+		6: {0, 128, 128, 0xff},
+		7: {0, 0, 0, 0xff},
+		8: {128, 128, 128, 0xff},
+	}
+
+	// 格子上的數字使用 5x7 點陣字，放大 3 倍
+	numberGlyphs = [9][7]string{
+		1: {"..#..", ".##..", "..#..", "..#..", "..#..", "..#..", ".###."},
+		2: {".###.", "#...#", "....#", "..##.", ".#...", "#....", "#####"},
+		3: {"####.", "....#", "....#", ".###.", "....#", "....#", "####."},
+		4: {"...#.", "..##.", ".#.#.", "#..#.", "#####", "...#.", "...#."},
+		5: {"#####", "#....", "####.", "....#", "....#", "#...#", ".###."},
+		6: {".###.", "#....", "#....", "####.", "#...#", "#...#", ".###."},
+		7: {"#####", "....#", "...#.", "..#..", ".#...", ".#...", ".#..."},
+		8: {".###.", "#...#", "#...#", ".###.", "#...#", "#...#", ".###."},
+	}
+
+	// 七段顯示器每個數字亮起的段 (a 上、b 右上、c 右下、d 下、e 左下、f 左上、g 中)
+	segments = [10]string{"abcdef", "bc", "abged", "abgcd", "fgbc", "afgcd", "afgedc", "abc", "abcdefg", "abcdfg"}
+)
+
+type canvas struct {
+	*image.RGBA
+}
+
+// fill - 填滿矩形
+func (c canvas) fill(rect image.Rectangle, clr color.RGBA) {
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			c.SetRGBA(x, y, clr)
+		}
+	}
+}
+
+// fillFunc - 將 inside 回傳 true 的 pixel 填上顏色，座標為 pixel 中心
+func (c canvas) fillFunc(rect image.Rectangle, clr color.RGBA, inside func(x, y float64) bool) {
+	for y := rect.Min.Y; y < rect.Max.Y; y++ {
+		for x := rect.Min.X; x < rect.Max.X; x++ {
+			if inside(float64(x)+0.5, float64(y)+0.5) {
+				c.SetRGBA(x, y, clr)
+			}
+		}
+	}
+}
+
+// circle - 實心圓
+func (c canvas) circle(centerX, centerY, radius float64, clr color.RGBA) {
+	c.fillFunc(c.Bounds(), clr, func(x, y float64) bool {
+		return math.Hypot(x-centerX, y-centerY) <= radius
+	})
+}
+
+// line - 有粗細的線段
+func (c canvas) line(x0, y0, x1, y1, width float64, clr color.RGBA) {
+	c.fillFunc(c.Bounds(), clr, func(x, y float64) bool {
+		dx, dy := x1-x0, y1-y0
+		t := math.Max(0, math.Min(1, ((x-x0)*dx+(y-y0)*dy)/(dx*dx+dy*dy)))
+		return math.Hypot(x-(x0+t*dx), y-(y0+t*dy)) <= width/2
+	})
+}
+
+// bevel - 立體邊框，左上亮、右下暗
+func (c canvas) bevel(rect image.Rectangle, width int, light, dark color.RGBA) {
+	for i := 0; i < width; i++ {
+		c.fill(image.Rect(rect.Min.X, rect.Min.Y+i, rect.Max.X-i, rect.Min.Y+i+1), light)
+		c.fill(image.Rect(rect.Min.X+i, rect.Min.Y, rect.Min.X+i+1, rect.Max.Y-i), light)
+		c.fill(image.Rect(rect.Min.X+i+1, rect.Max.Y-i-1, rect.Max.X, rect.Max.Y-i), dark)
+		c.fill(image.Rect(rect.Max.X-i-1, rect.Min.Y+i+1, rect.Max.X-i, rect.Max.Y), dark)
+	}
+}
+
+// sevenSegment - 在 rect 內畫出七段顯示器的數字，off 的 Alpha 為 0 時不畫暗的段
+func (c canvas) sevenSegment(rect image.Rectangle, value int, thickness int, on, off color.RGBA) {
+	minX, minY, maxX, maxY := rect.Min.X, rect.Min.Y, rect.Max.X, rect.Max.Y
+	midY := (minY + maxY) / 2
+	half := thickness / 2
+	bars := map[rune]image.Rectangle{
+		'a': image.Rect(minX+thickness, minY, maxX-thickness, minY+thickness),
+		'b': image.Rect(maxX-thickness, minY+thickness, maxX, midY-half),
+		'c': image.Rect(maxX-thickness, midY+half+thickness%2, maxX, maxY-thickness),
+		'd': image.Rect(minX+thickness, maxY-thickness, maxX-thickness, maxY),
+		'e': image.Rect(minX, midY+half+thickness%2, minX+thickness, maxY-thickness),
+		'f': image.Rect(minX, minY+thickness, minX+thickness, midY-half),
+		'g': image.Rect(minX+thickness, midY-half, maxX-thickness, midY+half+thickness%2),
+	}
+	for segment, bar := range bars {
+		lit := false
+		for _, s := range segments[value] {
+			lit = lit || s == segment
+		}
+		switch {
+		case lit:
+			c.fill(bar, on)
+		case off.A > 0:
+			c.fill(bar, off)
+		}
+	}
+}
+
+// glyph - 以 scale 倍大小畫出點陣字
+func (c canvas) glyph(x, y int, rows [7]string, scale int, clr color.RGBA) {
+	for row, line := range rows {
+		for col, dot := range line {
+			if dot == '#' {
+				c.fill(image.Rect(x+col*scale, y+row*scale, x+(col+1)*scale, y+(row+1)*scale), clr)
+			}
+		}
+	}
+}
+
+// tile - 第 row 列第 col 個圖塊的範圍
+func tile(row, col int) image.Rectangle {
+	return image.Rect(col*tileSize, row*tileSize, (col+1)*tileSize, (row+1)*tileSize)
+}
+
+func drawCovered(c canvas, rect image.Rectangle) {
+	c.fill(rect, lightGray)
+	c.bevel(rect, 3, white, darkGray)
+}
+
+func drawRevealed(c canvas, rect image.Rectangle, background color.RGBA) {
+	c.fill(rect, background)
+	c.fill(image.Rect(rect.Min.X, rect.Min.Y, rect.Max.X, rect.Min.Y+1), darkGray)
+	c.fill(image.Rect(rect.Min.X, rect.Min.Y, rect.Min.X+1, rect.Max.Y), darkGray)
+}
+
+func drawMine(c canvas, rect image.Rectangle) {
+	centerX, centerY := float64(rect.Min.X)+16, float64(rect.Min.Y)+16
+	sub := canvas{c.SubImage(rect).(*image.RGBA)}
+	sub.line(centerX-12, centerY, centerX+12, centerY, 2, black)
+	sub.line(centerX, centerY-12, centerX, centerY+12, 2, black)
+	sub.line(centerX-8, centerY-8, centerX+8, centerY+8, 2, black)
+	sub.line(centerX-8, centerY+8, centerX+8, centerY-8, 2, black)
+	sub.circle(centerX, centerY, 8, black)
+	sub.circle(centerX-3, centerY-3, 2, white)
+}
+
+func drawFlag(c canvas, rect image.Rectangle) {
+	x, y := rect.Min.X, rect.Min.Y
+	c.fill(image.Rect(x+17, y+6, x+19, y+24), black)
+	c.fill(image.Rect(x+12, y+22, x+23, y+24), black)
+	c.fill(image.Rect(x+8, y+24, x+26, y+27), black)
+	sub := canvas{c.SubImage(rect).(*image.RGBA)}
+	sub.fillFunc(rect, red, func(px, py float64) bool {
+		// 三角形旗面，尖端朝左
+		px, py = px-float64(x), py-float64(y)
+		return px <= 18 && px >= 6+12*math.Abs(py-11.5)/6 && py >= 5 && py <= 18
+	})
+}
+
+func drawFace(c canvas, rect image.Rectangle, expression string) {
+	drawCovered(c, rect)
+	sub := canvas{c.SubImage(rect).(*image.RGBA)}
+	centerX, centerY := float64(rect.Min.X)+16, float64(rect.Min.Y)+16
+	sub.circle(centerX, centerY, 12, black)
+	sub.circle(centerX, centerY, 11, yellow)
+	switch expression {
+	case skin.FaceLost:
+		for _, eyeX := range []float64{centerX - 5, centerX + 5} {
+			sub.line(eyeX-2, centerY-6, eyeX+2, centerY-2, 1.5, black)
+			sub.line(eyeX-2, centerY-2, eyeX+2, centerY-6, 1.5, black)
+		}
+	case skin.FaceWon:
+		sub.line(centerX-10, centerY-4, centerX+10, centerY-4, 1.5, black)
+		sub.circle(centerX-5, centerY-3, 3.5, black)
+		sub.circle(centerX+5, centerY-3, 3.5, black)
+	default:
+		sub.circle(centerX-4, centerY-4, 1.5, black)
+		sub.circle(centerX+4, centerY-4, 1.5, black)
+	}
+	switch expression {
+	case skin.FacePressed:
+		sub.circle(centerX, centerY+5, 3.5, black)
+		sub.circle(centerX, centerY+5, 2, yellow)
+	case skin.FaceLost:
+		// 嘴角向下
+		sub.fillFunc(rect, black, func(x, y float64) bool {
+			distance := math.Hypot(x-centerX, y-(centerY+11))
+			return distance >= 5 && distance <= 6.5 && y < centerY+8
+		})
+	default:
+		// 嘴角向上
+		sub.fillFunc(rect, black, func(x, y float64) bool {
+			distance := math.Hypot(x-centerX, y-centerY)
+			return distance >= 6 && distance <= 7.5 && y > centerY+2
+		})
+	}
+}
+
+func main() {
+	img := canvas{image.NewRGBA(image.Rect(0, 0, 8*tileSize, 3*tileSize+digitHeight))}
+	sprites := map[string]image.Rectangle{}
+
+	// 第一列：格子、旗子、地雷
+	sprites[skin.Covered] = tile(0, 0)
+	drawCovered(img, tile(0, 0))
+	sprites[skin.Revealed] = tile(0, 1)
+	drawRevealed(img, tile(0, 1), lightGray)
+	sprites[skin.Exploded] = tile(0, 2)
+	drawRevealed(img, tile(0, 2), red)
+	sprites[skin.Flag] = tile(0, 3)
+	drawFlag(img, tile(0, 3))
+	sprites[skin.Mine] = tile(0, 4)
+	drawMine(img, tile(0, 4))
+	sprites[skin.WrongFlag] = tile(0, 5)
+	drawRevealed(img, tile(0, 5), lightGray)
+	drawMine(img, tile(0, 5))
+	wrong := tile(0, 5)
+	crossX, crossY := float64(wrong.Min.X), float64(wrong.Min.Y)
+	sub := canvas{img.SubImage(wrong).(*image.RGBA)}
+	sub.line(crossX+6, crossY+6, crossX+26, crossY+26, 3, red)
+	sub.line(crossX+6, crossY+26, crossX+26, crossY+6, 3, red)
+
+	// 第二列：數字 1~8
+	for value := 1; value <= 8; value++ {
+		rect := tile(1, value-1)
+		sprites[skin.Number(value)] = rect
+		img.glyph(rect.Min.X+9, rect.Min.Y+6, numberGlyphs[value], 3, numberColors[value])
+	}
+
+	// 第三列：表情
+	for index, expression := range []string{skin.FacePlaying, skin.FacePressed, skin.FaceLost, skin.FaceWon} {
+		sprites[expression] = tile(2, index)
+		drawFace(img, tile(2, index), expression)
+	}
+
+	// 第四列：LED 數字 0~9
+	for value := 0; value <= 9; value++ {
+		rect := image.Rect(value*digitWidth, 3*tileSize, (value+1)*digitWidth, 3*tileSize+digitHeight)
+		sprites[skin.Digit(value)] = rect
+		img.fill(rect, black)
+		img.sevenSegment(rect.Inset(2), value, 3, red, dimRed)
+	}
+
+	file, err := os.Create("default.png")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := png.Encode(file, img); err != nil {
+		log.Fatal(err)
+	}
+	if err := file.Close(); err != nil {
+		log.Fatal(err)
+	}
+
+	descriptor := skin.Descriptor{Name: "Classic sprites", Image: "default.png", Sprites: map[string]skin.Rect{}}
+	for name, rect := range sprites {
+		descriptor.Sprites[name] = skin.Rect{X: rect.Min.X, Y: rect.Min.Y, W: rect.Dx(), H: rect.Dy()}
+	}
+	data, err := json.MarshalIndent(descriptor, "", "  ")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("default.json", append(data, '\n'), 0o644); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("generated %d sprites\n", len(sprites))
+}
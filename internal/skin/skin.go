@@ -0,0 +1,120 @@
+package skin
+
+//go:generate go run gen.go
+
+import (
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io/fs"
+	"path"
+)
+
+// 精靈圖上每個圖塊的名稱
+const (
+	Covered     = "covered"     // 未翻開的格子
+	Revealed    = "revealed"    // 已翻開的空白格子
+	Exploded    = "exploded"    // 踩到地雷的格子背景
+	Flag        = "flag"        // 旗子，畫在 Covered 上
+	Mine        = "mine"        // 地雷，畫在 Revealed 或 Exploded 上
+	WrongFlag   = "wrongFlag"   // 遊戲結束時插錯的旗子
+	FacePlaying = "facePlaying" // 遊戲進行中的表情
+	FacePressed = "facePressed" // 按住格子時的表情
+	FaceLost    = "faceLost"    // 失敗時的表情
+	FaceWon     = "faceWon"     // 獲勝時的表情
+)
+
+// DescriptorName - 內建 skin 的描述檔名稱
+const DescriptorName = "default.json"
+
+//go:embed default.json default.png
+var defaultFS embed.FS
+
+// ErrMissingSprite - 描述檔缺少必要的圖塊
+var ErrMissingSprite = errors.New("skin: missing sprite")
+
+// Number - 周圍地雷數 1~8 的圖塊名稱
+func Number(value int) string {
+	return fmt.Sprintf("number%d", value)
+}
+
+// Digit - LED 計數器數字 0~9 的圖塊名稱
+func Digit(value int) string {
+	return fmt.Sprintf("digit%d", value)
+}
+
+// SpriteNames - 一個 skin 必須提供的所有圖塊
+func SpriteNames() []string {
+	names := []string{Covered, Revealed, Exploded, Flag, Mine, WrongFlag, FacePlaying, FacePressed, FaceLost, FaceWon}
+	for value := 1; value <= 8; value++ {
+		names = append(names, Number(value))
+	}
+	for value := 0; value <= 9; value++ {
+		names = append(names, Digit(value))
+	}
+	return names
+}
+
+// Rect - 圖塊在精靈圖上的範圍
+type Rect struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	W int `json:"w"`
+	H int `json:"h"`
+}
+
+// Descriptor - skin 描述檔，Image 為相對於描述檔的 PNG 路徑
+type Descriptor struct {
+	Name    string          `json:"name"`
+	Image   string          `json:"image"`
+	Sprites map[string]Rect `json:"sprites"`
+}
+
+// Skin - 已讀取的精靈圖與每個圖塊的範圍
+type Skin struct {
+	Name    string
+	Image   image.Image
+	Sprites map[string]image.Rectangle
+}
+
+// Default - 內建的 skin (由 gen.go 產生)
+func Default() (*Skin, error) {
+	return Load(defaultFS, DescriptorName)
+}
+
+// Load - 從 fsys 讀取描述檔與精靈圖，並檢查所有圖塊都在圖片範圍內
+func Load(fsys fs.FS, descriptorName string) (*Skin, error) {
+	data, err := fs.ReadFile(fsys, descriptorName)
+	if err != nil {
+		return nil, err
+	}
+	var descriptor Descriptor
+	if err := json.Unmarshal(data, &descriptor); err != nil {
+		return nil, fmt.Errorf("skin: %s: %w", descriptorName, err)
+	}
+	file, err := fsys.Open(path.Join(path.Dir(descriptorName), descriptor.Image))
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	img, err := png.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("skin: %s: %w", descriptor.Image, err)
+	}
+	sprites := make(map[string]image.Rectangle, len(descriptor.Sprites))
+	for _, name := range SpriteNames() {
+		rect, ok := descriptor.Sprites[name]
+		if !ok {
+			return nil, fmt.Errorf("%w %q", ErrMissingSprite, name)
+		}
+		bounds := image.Rect(rect.X, rect.Y, rect.X+rect.W, rect.Y+rect.H)
+		if bounds.Empty() || !bounds.In(img.Bounds()) {
+			return nil, fmt.Errorf("skin: sprite %q %v outside image %v", name, bounds, img.Bounds())
+		}
+		sprites[name] = bounds
+	}
+	return &Skin{Name: descriptor.Name, Image: img, Sprites: sprites}, nil
+}
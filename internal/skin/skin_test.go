@@ -0,0 +1,107 @@
+package skin
+
+import (
+	"bytes"
+	"encoding/json"
+	"image"
+	"image/png"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefault(t *testing.T) {
+	skin, err := Default()
+	require.NoError(t, err)
+	assert.Equal(t, "Classic sprites", skin.Name)
+	for _, name := range SpriteNames() {
+		rect, ok := skin.Sprites[name]
+		assert.True(t, ok, name)
+		assert.True(t, rect.In(skin.Image.Bounds()), name)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 16, 16))))
+	sheet := buf.Bytes()
+	descriptor := func(modify func(sprites map[string]Rect)) []byte {
+		sprites := make(map[string]Rect)
+		for _, name := range SpriteNames() {
+			sprites[name] = Rect{X: 0, Y: 0, W: 8, H: 8}
+		}
+		if modify != nil {
+			modify(sprites)
+		}
+		data, err := json.Marshal(Descriptor{Name: "test", Image: "sheet.png", Sprites: sprites})
+		require.NoError(t, err)
+		return data
+	}
+	tests := []struct {
+		name    string
+		fsys    fstest.MapFS
+		file    string
+		wantErr bool
+		wantIs  error
+	}{
+		{
+			name: "load from sub directory",
+			fsys: fstest.MapFS{
+				"skins/test.json": {Data: descriptor(nil)},
+				"skins/sheet.png": {Data: sheet},
+			},
+			file: "skins/test.json",
+		},
+		{
+			name: "missing sprite",
+			fsys: fstest.MapFS{
+				"test.json": {Data: descriptor(func(sprites map[string]Rect) { delete(sprites, WrongFlag) })},
+				"sheet.png": {Data: sheet},
+			},
+			file:    "test.json",
+			wantErr: true,
+			wantIs:  ErrMissingSprite,
+		},
+		{
+			name: "sprite outside image",
+			fsys: fstest.MapFS{
+				"test.json": {Data: descriptor(func(sprites map[string]Rect) { sprites[Mine] = Rect{X: 12, Y: 0, W: 8, H: 8} })},
+				"sheet.png": {Data: sheet},
+			},
+			file:    "test.json",
+			wantErr: true,
+		},
+		{
+			name: "image is not png",
+			fsys: fstest.MapFS{
+				"test.json": {Data: descriptor(nil)},
+				"sheet.png": {Data: []byte("not a png")},
+			},
+			file:    "test.json",
+			wantErr: true,
+		},
+		{
+			name:    "missing descriptor",
+			fsys:    fstest.MapFS{},
+			file:    "test.json",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			skin, err := Load(tt.fsys, tt.file)
+			if tt.wantErr {
+				assert.Error(t, err)
+				if tt.wantIs != nil {
+					assert.ErrorIs(t, err, tt.wantIs)
+				}
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "test", skin.Name)
+			assert.Len(t, skin.Sprites, len(SpriteNames()))
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package solver
+
+import (
+	"sync"
+	"time"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+)
+
+// BenchmarkResult - 多局 solver 對局的統計
+type BenchmarkResult struct {
+	Games         int
+	Wins          int
+	NoGuessWins   int // 完全不需要猜測就獲勝的局數
+	Guesses       int
+	TotalDuration time.Duration
+}
+
+// WinRate - 勝率
+func (r BenchmarkResult) WinRate() float64 {
+	if r.Games == 0 {
+		return 0
+	}
+	return float64(r.Wins) / float64(r.Games)
+}
+
+// AverageGuesses - 每局平均猜測次數
+func (r BenchmarkResult) AverageGuesses() float64 {
+	if r.Games == 0 {
+		return 0
+	}
+	return float64(r.Guesses) / float64(r.Games)
+}
+
+// AverageTime - 每局平均時間
+func (r BenchmarkResult) AverageTime() time.Duration {
+	if r.Games == 0 {
+		return 0
+	}
+	return r.TotalDuration / time.Duration(r.Games)
+}
+
+// add - 累計單局結果
+func (r *BenchmarkResult) add(result PlayResult) {
+	r.Games++
+	r.Guesses += result.Guesses
+	r.TotalDuration += result.Duration
+	if result.Won {
+		r.Wins++
+		if result.Guesses == 0 {
+			r.NoGuessWins++
+		}
+	}
+}
+
+// Benchmark - 以 workers 個 goroutine 平行進行 games 局，第 i 局使用 seed+i 建立盤面
+func Benchmark(setup game.LevelSetup, games int, seed int64, workers int) BenchmarkResult {
+	if workers < 1 {
+		workers = 1
+	}
+	jobs := make(chan int64)
+	results := make(chan PlayResult)
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for gameSeed := range jobs {
+				results <- Play(game.NewGameWithSeed(setup.Rows, setup.Cols, setup.MineCounts, gameSeed))
+			}
+		}()
+	}
+	go func() {
+		for i := 0; i < games; i++ {
+			jobs <- seed + int64(i)
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	var benchmark BenchmarkResult
+	for result := range results {
+		benchmark.add(result)
+	}
+	return benchmark
+}
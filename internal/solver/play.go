@@ -0,0 +1,48 @@
+package solver
+
+import (
+	"time"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+)
+
+// PlayResult - 由 solver 自動進行一局的結果
+type PlayResult struct {
+	Won      bool
+	Moves    int           // 翻開次數
+	Guesses  int           // 第一次點擊之後，沒有可證明安全的格子時依最低機率猜測的次數
+	ThreeBV  int           // 盤面 3BV
+	Duration time.Duration // 包含 solver 推算時間
+}
+
+// Play - 讓 solver 玩完一局：先翻開所有可證明安全的格子，沒有時猜地雷機率最低的格子
+func Play(gameInstance *game.Game) PlayResult {
+	result := PlayResult{ThreeBV: gameInstance.Board.ThreeBV()}
+	startTime := time.Now()
+	for !gameInstance.IsGameOver && !gameInstance.IsPlayerWin {
+		analysis := Analyze(gameInstance.Board.VisibleRows(), gameInstance.MineCounts)
+		if len(analysis.Safe) > 0 {
+			for _, cell := range analysis.Safe {
+				if gameInstance.Board.GetCell(cell.Row, cell.Col).Revealed {
+					continue
+				}
+				gameInstance.RevealCell(cell.Row, cell.Col)
+				result.Moves++
+			}
+			continue
+		}
+		cell, _, found := analysis.Best()
+		if !found {
+			break
+		}
+		// 第一次點擊沒有任何資訊，不算在猜測次數內
+		if result.Moves > 0 {
+			result.Guesses++
+		}
+		gameInstance.RevealCell(cell.Row, cell.Col)
+		result.Moves++
+	}
+	result.Duration = time.Since(startTime)
+	result.Won = gameInstance.IsPlayerWin
+	return result
+}
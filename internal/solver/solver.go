@@ -0,0 +1,454 @@
+package solver
+
+import (
+	"math"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+)
+
+// maxSearchNodes - 單一 frontier 區塊列舉的節點上限，超過時改用近似機率
+const maxSearchNodes = 1 << 20
+
+// Cell - 盤面上的座標
+type Cell struct {
+	Row int
+	Col int
+}
+
+// Analysis - 根據可見盤面推算的結果
+type Analysis struct {
+	Rows        int
+	Cols        int
+	Probability [][]float64 // 未翻開格子為地雷的機率，已翻開的格子為 -1
+	Safe        []Cell      // 可以證明安全的未翻開格子
+	Mines       []Cell      // 可以證明是地雷的未翻開格子
+	Exact       bool        // 機率是否為精確值，frontier 過大時會退回近似值
+}
+
+// constraint - 一個數字格對周圍未知格子的限制：vars 中恰好有 mines 個地雷
+type constraint struct {
+	vars  []int
+	mines int
+}
+
+// component - 彼此透過限制相連的 frontier 格子
+type component struct {
+	vars        []int
+	constraints []int
+	// solutions[k] - 此區塊恰好有 k 個地雷的解的個數
+	solutions []float64
+	// mineCounts[k][i] - 此區塊有 k 個地雷的解中，vars[i] 是地雷的解的個數
+	mineCounts [][]float64
+	exact      bool
+}
+
+// Analyze - 由 game.Board.VisibleRows 的編碼與總地雷數推算每個未翻開格子的地雷機率
+//
+// 插旗的格子一律當成未知格子處理，不信任玩家的標記
+func Analyze(visibleRows []string, totalMines int) Analysis {
+	rows := len(visibleRows)
+	cols := 0
+	if rows > 0 {
+		cols = len(visibleRows[0])
+	}
+	analysis := Analysis{Rows: rows, Cols: cols, Exact: true}
+	analysis.Probability = make([][]float64, rows)
+	for row := range analysis.Probability {
+		analysis.Probability[row] = make([]float64, cols)
+	}
+
+	// 收集未知格子與已知地雷
+	varIndex := make([]int, rows*cols)
+	var unknowns []Cell
+	knownMines := 0
+	for row, line := range visibleRows {
+		for col := 0; col < cols; col++ {
+			varIndex[row*cols+col] = -1
+			switch line[col] {
+			case game.ViewHidden, game.ViewFlag:
+				varIndex[row*cols+col] = len(unknowns)
+				unknowns = append(unknowns, Cell{Row: row, Col: col})
+			case game.ViewMine, game.ViewFlaggedMine:
+				knownMines++
+				analysis.Probability[row][col] = -1
+			default:
+				analysis.Probability[row][col] = -1
+			}
+		}
+	}
+	if len(unknowns) == 0 {
+		return analysis
+	}
+
+	// 建立數字格的限制
+	var constraints []constraint
+	varConstraints := make([][]int, len(unknowns))
+	for row, line := range visibleRows {
+		for col := 0; col < cols; col++ {
+			ch := line[col]
+			if ch < '0' || ch > '8' {
+				continue
+			}
+			current := constraint{mines: int(ch - '0')}
+			for _, neighbor := range neighbors(row, col, rows, cols) {
+				switch visibleRows[neighbor.Row][neighbor.Col] {
+				case game.ViewMine, game.ViewFlaggedMine:
+					current.mines--
+				case game.ViewHidden, game.ViewFlag:
+					current.vars = append(current.vars, varIndex[neighbor.Row*cols+neighbor.Col])
+				}
+			}
+			if len(current.vars) == 0 {
+				continue
+			}
+			for _, v := range current.vars {
+				varConstraints[v] = append(varConstraints[v], len(constraints))
+			}
+			constraints = append(constraints, current)
+		}
+	}
+
+	components := buildComponents(constraints, varConstraints)
+	for _, comp := range components {
+		comp.enumerate(constraints, varConstraints)
+		if !comp.exact {
+			analysis.Exact = false
+		}
+	}
+
+	// 不在 frontier 上的未知格子
+	interior := 0
+	for v := range unknowns {
+		if len(varConstraints[v]) == 0 {
+			interior++
+		}
+	}
+	remaining := totalMines - knownMines
+	probabilities, interiorProbability, ok := combine(components, interior, remaining)
+	if !ok {
+		analysis.Exact = false
+		probabilities, interiorProbability = approximate(components, constraints, varConstraints, interior, remaining)
+	}
+
+	for v, cell := range unknowns {
+		p := interiorProbability
+		if len(varConstraints[v]) != 0 {
+			p = probabilities[v]
+		}
+		analysis.Probability[cell.Row][cell.Col] = p
+		if !analysis.Exact && !certain(components, v) {
+			continue
+		}
+		switch p {
+		case 0:
+			analysis.Safe = append(analysis.Safe, cell)
+		case 1:
+			analysis.Mines = append(analysis.Mines, cell)
+		}
+	}
+	return analysis
+}
+
+// certain - 在近似模式下，只有透過完整列舉的區塊才能給出確定的結論
+func certain(components []*component, v int) bool {
+	for _, comp := range components {
+		for _, compVar := range comp.vars {
+			if compVar == v {
+				return comp.exact
+			}
+		}
+	}
+	return false
+}
+
+// Best - 找出地雷機率最低的未翻開格子，沒有未翻開格子時回傳 false
+func (a Analysis) Best() (Cell, float64, bool) {
+	best, bestProbability, found := Cell{}, 2.0, false
+	for row := range a.Probability {
+		for col, p := range a.Probability[row] {
+			if p < 0 || p >= bestProbability {
+				continue
+			}
+			best, bestProbability, found = Cell{Row: row, Col: col}, p, true
+		}
+	}
+	return best, bestProbability, found
+}
+
+// IsSafe - 該格是否可以證明安全
+func (a Analysis) IsSafe(row, col int) bool {
+	for _, cell := range a.Safe {
+		if cell.Row == row && cell.Col == col {
+			return true
+		}
+	}
+	return false
+}
+
+// IsMine - 該格是否可以證明是地雷
+func (a Analysis) IsMine(row, col int) bool {
+	for _, cell := range a.Mines {
+		if cell.Row == row && cell.Col == col {
+			return true
+		}
+	}
+	return false
+}
+
+// neighbors - 鄰近且在邊界內的格子
+func neighbors(row, col, rows, cols int) []Cell {
+	result := make([]Cell, 0, 8)
+	for dRow := -1; dRow <= 1; dRow++ {
+		for dCol := -1; dCol <= 1; dCol++ {
+			neighborRow, neighborCol := row+dRow, col+dCol
+			if (dRow == 0 && dCol == 0) ||
+				neighborRow < 0 || neighborRow >= rows ||
+				neighborCol < 0 || neighborCol >= cols {
+				continue
+			}
+			result = append(result, Cell{Row: neighborRow, Col: neighborCol})
+		}
+	}
+	return result
+}
+
+// buildComponents - 以 BFS 將共用限制的 frontier 格子分組，順序讓相鄰格子盡量連續以利剪枝
+func buildComponents(constraints []constraint, varConstraints [][]int) []*component {
+	visitedVar := make([]bool, len(varConstraints))
+	visitedConstraint := make([]bool, len(constraints))
+	var components []*component
+	for start := range varConstraints {
+		if visitedVar[start] || len(varConstraints[start]) == 0 {
+			continue
+		}
+		comp := &component{}
+		visitQueue := []int{start}
+		visitedVar[start] = true
+		for len(visitQueue) > 0 {
+			v := visitQueue[0]
+			visitQueue = visitQueue[1:]
+			comp.vars = append(comp.vars, v)
+			for _, c := range varConstraints[v] {
+				if visitedConstraint[c] {
+					continue
+				}
+				visitedConstraint[c] = true
+				comp.constraints = append(comp.constraints, c)
+				for _, next := range constraints[c].vars {
+					if !visitedVar[next] {
+						visitedVar[next] = true
+						visitQueue = append(visitQueue, next)
+					}
+				}
+			}
+		}
+		components = append(components, comp)
+	}
+	return components
+}
+
+// enumerate - 以回溯法列舉區塊內所有符合限制的地雷配置
+func (comp *component) enumerate(constraints []constraint, varConstraints [][]int) {
+	size := len(comp.vars)
+	comp.solutions = make([]float64, size+1)
+	comp.mineCounts = make([][]float64, size+1)
+	for k := range comp.mineCounts {
+		comp.mineCounts[k] = make([]float64, size)
+	}
+	// 限制目前已指定的地雷數與尚未指定的格子數
+	assignedMines := make([]int, len(constraints))
+	unassigned := make([]int, len(constraints))
+	for _, c := range comp.constraints {
+		unassigned[c] = len(constraints[c].vars)
+	}
+	assignment := make([]bool, size)
+	nodes := 0
+	comp.exact = true
+
+	var search func(index, mines int)
+	search = func(index, mines int) {
+		if !comp.exact {
+			return
+		}
+		nodes++
+		if nodes > maxSearchNodes {
+			comp.exact = false
+			return
+		}
+		if index == size {
+			comp.solutions[mines]++
+			for i, isMine := range assignment {
+				if isMine {
+					comp.mineCounts[mines][i]++
+				}
+			}
+			return
+		}
+		v := comp.vars[index]
+		for _, isMine := range [2]bool{false, true} {
+			valid := true
+			for _, c := range varConstraints[v] {
+				unassigned[c]--
+				if isMine {
+					assignedMines[c]++
+				}
+				if assignedMines[c] > constraints[c].mines ||
+					assignedMines[c]+unassigned[c] < constraints[c].mines {
+					valid = false
+				}
+			}
+			if valid {
+				assignment[index] = isMine
+				mineCount := mines
+				if isMine {
+					mineCount++
+				}
+				search(index+1, mineCount)
+				assignment[index] = false
+			}
+			for _, c := range varConstraints[v] {
+				unassigned[c]++
+				if isMine {
+					assignedMines[c]--
+				}
+			}
+		}
+	}
+	search(0, 0)
+}
+
+// logBinomial - log(C(n, k))，k 超出範圍時回傳 -Inf
+func logBinomial(n, k int) float64 {
+	if k < 0 || k > n {
+		return math.Inf(-1)
+	}
+	a, _ := math.Lgamma(float64(n + 1))
+	b, _ := math.Lgamma(float64(k + 1))
+	c, _ := math.Lgamma(float64(n - k + 1))
+	return a - b - c
+}
+
+// convolve - 合併兩個「地雷數 -> 解的個數」分佈
+func convolve(a, b []float64) []float64 {
+	result := make([]float64, len(a)+len(b)-1)
+	for i, x := range a {
+		if x == 0 {
+			continue
+		}
+		for j, y := range b {
+			result[i+j] += x * y
+		}
+	}
+	return result
+}
+
+// combine - 以總地雷數把各區塊與內部格子的解加權合併成每格機率
+func combine(components []*component, interior, remaining int) (map[int]float64, float64, bool) {
+	for _, comp := range components {
+		if !comp.exact {
+			return nil, 0, false
+		}
+	}
+	// interiorWeight(m) - frontier 共有 m 個地雷時，內部格子的配置數 (相對值)
+	maxLog := math.Inf(-1)
+	for m := 0; m <= remaining; m++ {
+		maxLog = math.Max(maxLog, logBinomial(interior, remaining-m))
+	}
+	if math.IsInf(maxLog, -1) {
+		return nil, 0, false
+	}
+	interiorWeight := func(m int) float64 {
+		if m > remaining {
+			return 0
+		}
+		return math.Exp(logBinomial(interior, remaining-m) - maxLog)
+	}
+
+	total := []float64{1}
+	for _, comp := range components {
+		total = convolve(total, comp.solutions)
+	}
+	totalWeight, interiorMines := 0.0, 0.0
+	for m, count := range total {
+		weight := count * interiorWeight(m)
+		totalWeight += weight
+		interiorMines += weight * float64(remaining-m)
+	}
+	if totalWeight == 0 {
+		return nil, 0, false
+	}
+	interiorProbability := 0.0
+	switch {
+	case interior == 0 || interiorMines == 0:
+	case interiorMines == totalWeight*float64(interior):
+		interiorProbability = 1
+	default:
+		interiorProbability = interiorMines / totalWeight / float64(interior)
+	}
+
+	probabilities := make(map[int]float64)
+	for i, comp := range components {
+		others := []float64{1}
+		for j, other := range components {
+			if i != j {
+				others = convolve(others, other.solutions)
+			}
+		}
+		// weightByK[k] - 此區塊有 k 個地雷時，其他部分的總權重
+		weightByK := make([]float64, len(comp.solutions))
+		for k := range comp.solutions {
+			for m, count := range others {
+				weightByK[k] += count * interiorWeight(k+m)
+			}
+		}
+		for index, v := range comp.vars {
+			mineWeight, allWeight := 0.0, 0.0
+			for k := range comp.solutions {
+				mineWeight += comp.mineCounts[k][index] * weightByK[k]
+				allWeight += comp.solutions[k] * weightByK[k]
+			}
+			switch {
+			case mineWeight == 0:
+				probabilities[v] = 0
+			case mineWeight == allWeight:
+				probabilities[v] = 1
+			default:
+				probabilities[v] = mineWeight / allWeight
+			}
+		}
+	}
+	return probabilities, interiorProbability, true
+}
+
+// approximate - 無法精確計算時，以區塊內的解 (若有) 或單一限制的比例估計機率
+func approximate(components []*component, constraints []constraint, varConstraints [][]int, interior, remaining int) (map[int]float64, float64) {
+	probabilities := make(map[int]float64)
+	expectedFrontierMines := 0.0
+	for _, comp := range components {
+		solutionCount := 0.0
+		for _, count := range comp.solutions {
+			solutionCount += count
+		}
+		for index, v := range comp.vars {
+			if comp.exact && solutionCount > 0 {
+				mineCount := 0.0
+				for k := range comp.solutions {
+					mineCount += comp.mineCounts[k][index]
+				}
+				probabilities[v] = mineCount / solutionCount
+			} else {
+				p := 0.0
+				for _, c := range varConstraints[v] {
+					p = math.Max(p, float64(constraints[c].mines)/float64(len(constraints[c].vars)))
+				}
+				probabilities[v] = p
+			}
+			expectedFrontierMines += probabilities[v]
+		}
+	}
+	interiorProbability := 0.0
+	if interior > 0 {
+		interiorProbability = math.Min(1, math.Max(0, (float64(remaining)-expectedFrontierMines)/float64(interior)))
+	}
+	return probabilities, interiorProbability
+}
@@ -0,0 +1,85 @@
+package solver
+
+import (
+	"testing"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyze(t *testing.T) {
+	tests := []struct {
+		name      string
+		rows      []string
+		mines     int
+		wantSafe  []Cell
+		wantMines []Cell
+	}{
+		{
+			name:      "one next to a single hidden cell",
+			rows:      []string{"1#", "11"},
+			mines:     1,
+			wantMines: []Cell{{Row: 0, Col: 1}},
+		},
+		{
+			name:      "one-two pattern",
+			rows:      []string{"###", "121", "000"},
+			mines:     2,
+			wantSafe:  []Cell{{Row: 0, Col: 1}},
+			wantMines: []Cell{{Row: 0, Col: 0}, {Row: 0, Col: 2}},
+		},
+		{
+			name:      "global mine count clears the interior",
+			rows:      []string{"1#1", "111", "###"},
+			mines:     1,
+			wantSafe:  []Cell{{Row: 2, Col: 0}, {Row: 2, Col: 1}, {Row: 2, Col: 2}},
+			wantMines: []Cell{{Row: 0, Col: 1}},
+		},
+		{
+			name:  "flags are not trusted",
+			rows:  []string{"F#", "11"},
+			mines: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			analysis := Analyze(tt.rows, tt.mines)
+			assert.True(t, analysis.Exact)
+			assert.Equal(t, tt.wantSafe, analysis.Safe)
+			assert.Equal(t, tt.wantMines, analysis.Mines)
+		})
+	}
+}
+
+func TestAnalyzeProbability(t *testing.T) {
+	// 上方的 1 旁邊兩個未知格子各 50%，下方的格子因此安全，剩下的地雷平均分佈在右側內部格子
+	analysis := Analyze([]string{"1##", "1##", "###"}, 2)
+	assert.InDelta(t, 0.5, analysis.Probability[0][1], 1e-9)
+	assert.InDelta(t, 0.5, analysis.Probability[1][1], 1e-9)
+	assert.InDelta(t, 1.0/3, analysis.Probability[2][2], 1e-9)
+	assert.Equal(t, 0.0, analysis.Probability[2][0])
+	assert.Equal(t, -1.0, analysis.Probability[0][0])
+	assert.Equal(t, []Cell{{Row: 2, Col: 0}, {Row: 2, Col: 1}}, analysis.Safe)
+
+	best, p, found := analysis.Best()
+	assert.True(t, found)
+	assert.Equal(t, Cell{Row: 2, Col: 0}, best)
+	assert.Equal(t, 0.0, p)
+}
+
+func TestPlayIsDeterministic(t *testing.T) {
+	setup := game.LevelSetupMap[game.Easy]
+	first := Play(game.NewGameWithSeed(setup.Rows, setup.Cols, setup.MineCounts, 3))
+	second := Play(game.NewGameWithSeed(setup.Rows, setup.Cols, setup.MineCounts, 3))
+	assert.Equal(t, first.Won, second.Won)
+	assert.Equal(t, first.Moves, second.Moves)
+	assert.Equal(t, first.Guesses, second.Guesses)
+}
+
+func TestBenchmark(t *testing.T) {
+	result := Benchmark(game.LevelSetupMap[game.Easy], 50, 1, 4)
+	assert.Equal(t, 50, result.Games)
+	assert.Greater(t, result.Wins, 0)
+	assert.LessOrEqual(t, result.NoGuessWins, result.Wins)
+	assert.Equal(t, Benchmark(game.LevelSetupMap[game.Easy], 50, 1, 1).Wins, result.Wins)
+}
@@ -0,0 +1,80 @@
+package solver
+
+import "github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+
+// LossKind - 失敗的原因分類
+type LossKind string
+
+const (
+	LossForced    LossKind = "forced"    // 當下沒有可以證明安全的格子，只能猜
+	LossAvoidable LossKind = "avoidable" // 還有可以證明安全的格子卻去猜
+	LossCareless  LossKind = "careless"  // 踩到可以證明是地雷的格子
+)
+
+// Verdict - 致命一擊的分析結果
+type Verdict struct {
+	Kind        LossKind
+	Probability float64  // 踩到的格子在當下是地雷的機率
+	Lowest      float64  // 當下所有未翻開格子中最低的地雷機率
+	Safe        int      // 當下可以證明安全的格子數
+	Analysis    Analysis // 致命一擊之前盤面的推論結果，供失敗回顧標示格子
+}
+
+// Unlucky - 是否為不得不猜而踩到地雷
+func (v Verdict) Unlucky() bool {
+	return v.Kind == LossForced
+}
+
+// JudgeLoss - 以致命一擊之前的可見盤面判斷踩到 exploded 是否可以避免
+func JudgeLoss(before []string, totalMines int, exploded Cell) Verdict {
+	analysis := Analyze(before, totalMines)
+	verdict := Verdict{
+		Kind:        LossForced,
+		Probability: analysis.Probability[exploded.Row][exploded.Col],
+		Safe:        len(analysis.Safe),
+		Analysis:    analysis,
+	}
+	if _, lowest, ok := analysis.Best(); ok {
+		verdict.Lowest = lowest
+	}
+	switch {
+	case analysis.IsMine(exploded.Row, exploded.Col):
+		verdict.Kind = LossCareless
+	case verdict.Safe > 0:
+		verdict.Kind = LossAvoidable
+	}
+	return verdict
+}
+
+// JudgeGame - 分析已經失敗的遊戲，還沒有踩到地雷時回傳 false
+func JudgeGame(gameInstance *game.Game) (Verdict, bool) {
+	before := gameInstance.BeforeLoss()
+	exploded, ok := gameInstance.Exploded()
+	if before == nil || !ok {
+		return Verdict{}, false
+	}
+	return JudgeLoss(before, gameInstance.MineCounts, Cell{Row: exploded.Row, Col: exploded.Col}), true
+}
+
+// LossJudge - 記住一局遊戲的失敗分析，統計與失敗回顧共用同一次分析
+type LossJudge struct {
+	gameInstance *game.Game
+	verdict      *Verdict
+}
+
+// NewLossJudge - 建立 gameInstance 的失敗分析
+func NewLossJudge(gameInstance *game.Game) *LossJudge {
+	return &LossJudge{gameInstance: gameInstance}
+}
+
+// Verdict - 第一次在失敗後呼叫時才分析，之後回傳相同的結果，還沒有踩到地雷時回傳 false
+func (j *LossJudge) Verdict() (Verdict, bool) {
+	if j.verdict == nil {
+		verdict, ok := JudgeGame(j.gameInstance)
+		if !ok {
+			return Verdict{}, false
+		}
+		j.verdict = &verdict
+	}
+	return *j.verdict, true
+}
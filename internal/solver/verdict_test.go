@@ -0,0 +1,88 @@
+package solver
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJudgeLoss(t *testing.T) {
+	tests := []struct {
+		name            string
+		rows            []string
+		mines           int
+		exploded        Cell
+		wantKind        LossKind
+		wantProbability float64
+		wantLowest      float64
+	}{
+		{
+			name:            "clicking a deducible mine",
+			rows:            []string{"1#", "11"},
+			mines:           1,
+			exploded:        Cell{Row: 0, Col: 1},
+			wantKind:        LossCareless,
+			wantProbability: 1,
+			wantLowest:      1,
+		},
+		{
+			name:            "guessing while a safe cell exists",
+			rows:            []string{"1##", "1##", "###"},
+			mines:           2,
+			exploded:        Cell{Row: 2, Col: 2},
+			wantKind:        LossAvoidable,
+			wantProbability: 1.0 / 3,
+		},
+		{
+			name:            "no safe cell to choose",
+			rows:            []string{"##", "##"},
+			mines:           1,
+			exploded:        Cell{Row: 1, Col: 1},
+			wantKind:        LossForced,
+			wantProbability: 0.25,
+			wantLowest:      0.25,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verdict := JudgeLoss(tt.rows, tt.mines, tt.exploded)
+			assert.Equal(t, tt.wantKind, verdict.Kind)
+			assert.InDelta(t, tt.wantProbability, verdict.Probability, 1e-9)
+			assert.InDelta(t, tt.wantLowest, verdict.Lowest, 1e-9)
+			assert.Equal(t, tt.wantKind == LossForced, verdict.Unlucky())
+		})
+	}
+}
+
+func TestJudgeGame(t *testing.T) {
+	gameInstance, err := game.LoadGame(strings.NewReader("*..\n...\n...\n"))
+	require.NoError(t, err)
+	_, ok := JudgeGame(gameInstance)
+	assert.False(t, ok)
+
+	gameInstance.RevealCell(0, 0)
+	verdict, ok := JudgeGame(gameInstance)
+	assert.True(t, ok)
+	assert.Equal(t, LossForced, verdict.Kind)
+	assert.InDelta(t, 1.0/9, verdict.Probability, 1e-9)
+	assert.Len(t, verdict.Analysis.Probability, 3)
+}
+
+func TestLossJudge(t *testing.T) {
+	gameInstance, err := game.LoadGame(strings.NewReader("*..\n...\n...\n"))
+	require.NoError(t, err)
+	judge := NewLossJudge(gameInstance)
+	_, ok := judge.Verdict()
+	assert.False(t, ok, "no verdict before the loss")
+
+	gameInstance.RevealCell(0, 0)
+	first, ok := judge.Verdict()
+	require.True(t, ok)
+	second, ok := judge.Verdict()
+	require.True(t, ok)
+	// 第二次呼叫回傳同一次分析的結果
+	assert.Same(t, &first.Analysis.Probability[0][0], &second.Analysis.Probability[0][0])
+}
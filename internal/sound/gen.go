@@ -0,0 +1,127 @@
+//go:build ignore
+
+// gen.go - 以程式合成內建音效，產生 16 bit 單聲道的 WAV 檔
+package main
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"os"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/sound"
+)
+
+// samples - 長度 seconds 的 sample 緩衝區
+func samples(seconds float64) []float64 {
+	return make([]float64, int(seconds*sound.SampleRate))
+}
+
+// tone - 在 buf 的 start 秒開始加入 duration 秒、頻率 freq 的正弦波，以指數衰減收尾
+func tone(buf []float64, start, duration, freq, gain, decay float64) {
+	from := int(start * sound.SampleRate)
+	for i := 0; i < int(duration*sound.SampleRate) && from+i < len(buf); i++ {
+		t := float64(i) / sound.SampleRate
+		// 前 3ms 淡入、最後 10ms 淡出避免爆音
+		envelope := math.Min(t/0.003, 1) * math.Min((duration-t)/0.01, 1)
+		buf[from+i] += gain * envelope * math.Exp(-decay*t) * math.Sin(2*math.Pi*freq*t)
+	}
+}
+
+// sweep - 頻率由 from 線性滑到 to 的正弦波
+func sweep(buf []float64, from, to, gain, decay float64) {
+	phase := 0.0
+	duration := float64(len(buf)) / sound.SampleRate
+	for i := range buf {
+		t := float64(i) / sound.SampleRate
+		freq := from + (to-from)*t/duration
+		phase += 2 * math.Pi * freq / sound.SampleRate
+		attack := math.Min(t/0.003, 1)
+		buf[i] += gain * attack * math.Exp(-decay*t) * math.Sin(phase)
+	}
+}
+
+// noise - 經過一階低通濾波的白噪音，cutoff 越小越低沉
+func noise(buf []float64, gain, decay, cutoff float64) {
+	random := rand.New(rand.NewSource(1))
+	alpha := 1 - math.Exp(-2*math.Pi*cutoff/sound.SampleRate)
+	value := 0.0
+	for i := range buf {
+		t := float64(i) / sound.SampleRate
+		value += alpha * (random.Float64()*2 - 1 - value)
+		buf[i] += gain * math.Exp(-decay*t) * value * 3
+	}
+}
+
+// encode - 轉成 16 bit 單聲道的 WAV 檔
+func encode(buf []float64) []byte {
+	var out bytes.Buffer
+	dataSize := uint32(len(buf) * 2)
+	write := func(v any) {
+		if err := binary.Write(&out, binary.LittleEndian, v); err != nil {
+			log.Fatal(err)
+		}
+	}
+	out.WriteString("RIFF")
+	write(36 + dataSize)
+	out.WriteString("WAVEfmt ")
+	write(uint32(16))                   // fmt chunk 大小
+	write(uint16(1))                    // PCM
+	write(uint16(1))                    // 單聲道
+	write(uint32(sound.SampleRate))     // 取樣頻率
+	write(uint32(sound.SampleRate * 2)) // 每秒 byte 數
+	write(uint16(2))                    // 每個 sample 的 byte 數
+	write(uint16(16))                   // bit 數
+	out.WriteString("data")
+	write(dataSize)
+	for _, sample := range buf {
+		write(int16(math.Max(-1, math.Min(1, sample)) * math.MaxInt16))
+	}
+	return out.Bytes()
+}
+
+func main() {
+	sounds := map[string][]float64{}
+
+	reveal := samples(0.06)
+	tone(reveal, 0, 0.06, 1200, 0.5, 60)
+	sounds[sound.Reveal] = reveal
+
+	cascade := samples(0.22)
+	sweep(cascade, 400, 1400, 0.45, 12)
+	sounds[sound.Cascade] = cascade
+
+	flag := samples(0.12)
+	tone(flag, 0, 0.06, 660, 0.4, 30)
+	tone(flag, 0.05, 0.07, 880, 0.4, 30)
+	sounds[sound.Flag] = flag
+
+	chord := samples(0.16)
+	for index, freq := range []float64{523.25, 659.25, 783.99} {
+		tone(chord, float64(index)*0.025, 0.12, freq, 0.25, 25)
+	}
+	sounds[sound.Chord] = chord
+
+	explosion := samples(0.8)
+	noise(explosion, 0.6, 5, 600)
+	tone(explosion, 0, 0.4, 60, 0.5, 8)
+	sounds[sound.Explosion] = explosion
+
+	victory := samples(0.8)
+	for index, freq := range []float64{523.25, 659.25, 783.99} {
+		tone(victory, float64(index)*0.11, 0.12, freq, 0.35, 12)
+	}
+	tone(victory, 0.33, 0.47, 1046.5, 0.35, 5)
+	tone(victory, 0.33, 0.47, 783.99, 0.2, 5)
+	sounds[sound.Victory] = victory
+
+	for _, name := range sound.Names() {
+		if err := os.WriteFile(name+".wav", encode(sounds[name]), 0o644); err != nil {
+			log.Fatal(err)
+		}
+	}
+	fmt.Printf("generated %d sounds\n", len(sounds))
+}
@@ -0,0 +1,38 @@
+package sound
+
+//go:generate go run gen.go
+
+import (
+	"embed"
+	"fmt"
+)
+
+// SampleRate - 內建音效的取樣頻率
+const SampleRate = 44100
+
+// 內建音效的名稱
+const (
+	Reveal    = "reveal"    // 翻開單一格子
+	Cascade   = "cascade"   // flood fill 連帶翻開多個格子
+	Flag      = "flag"      // 插旗或取消插旗
+	Chord     = "chord"     // chord
+	Explosion = "explosion" // 踩到地雷
+	Victory   = "victory"   // 獲勝
+)
+
+//go:embed *.wav
+var soundFS embed.FS
+
+// Names - 所有內建音效
+func Names() []string {
+	return []string{Reveal, Cascade, Flag, Chord, Explosion, Victory}
+}
+
+// WAV - 取出內建音效的 WAV 檔內容 (16 bit 單聲道)
+func WAV(name string) ([]byte, error) {
+	data, err := soundFS.ReadFile(name + ".wav")
+	if err != nil {
+		return nil, fmt.Errorf("sound: %w", err)
+	}
+	return data, nil
+}
@@ -0,0 +1,27 @@
+package sound
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWAV(t *testing.T) {
+	for _, name := range Names() {
+		t.Run(name, func(t *testing.T) {
+			data, err := WAV(name)
+			require.NoError(t, err)
+			require.Greater(t, len(data), 44)
+			assert.Equal(t, "RIFF", string(data[0:4]))
+			assert.Equal(t, "WAVE", string(data[8:12]))
+			assert.Equal(t, uint16(1), binary.LittleEndian.Uint16(data[22:24]), "mono")
+			assert.Equal(t, uint32(SampleRate), binary.LittleEndian.Uint32(data[24:28]))
+			assert.Equal(t, uint16(16), binary.LittleEndian.Uint16(data[34:36]), "bit depth")
+			assert.Equal(t, uint32(len(data)-44), binary.LittleEndian.Uint32(data[40:44]))
+		})
+	}
+	_, err := WAV("missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,211 @@
+package stats
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/solver"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/storage"
+)
+
+// FileName - 統計資料檔名稱
+const FileName = "stats.json"
+
+// HistogramBuckets - 時間分佈圖的區間數
+const HistogramBuckets = 8
+
+// GameResult - 一場結束的遊戲
+type GameResult struct {
+	Level        game.Level      `json:"level"`
+	Won          bool            `json:"won"`
+	Milliseconds int64           `json:"ms"`
+	Date         time.Time       `json:"date"`
+	Seed         int64           `json:"seed"`
+	Hints        int             `json:"hints,omitempty"` // 使用提示的次數，大於 0 代表有輔助
+	Loss         solver.LossKind `json:"loss,omitempty"`  // 失敗的原因，獲勝或無法分析時為空
+	Risk         float64         `json:"risk,omitempty"`  // 踩到的格子在當下是地雷的機率
+}
+
+// Assisted - 這局是否使用過提示
+func (r GameResult) Assisted() bool {
+	return r.Hints > 0
+}
+
+// Bucket - 時間分佈圖的一個區間 [From, To)
+type Bucket struct {
+	From  time.Duration
+	To    time.Duration
+	Count int
+}
+
+// Summary - 單一難度的生涯統計
+type Summary struct {
+	Played        int
+	Won           int
+	WinRate       float64
+	CurrentStreak int // 目前連勝場數
+	LongestStreak int // 最長連勝場數
+	Assisted      int // 使用過提示的局數
+	Unlucky       int // 沒有可以證明安全的格子，只能猜而失敗的局數
+	Careless      int // 還有可以證明安全的格子或踩到可推論的地雷而失敗的局數
+	AverageTime   time.Duration
+	MedianTime    time.Duration
+	Histogram     []Bucket // 獲勝時間分佈
+}
+
+// Store - 所有結束的遊戲紀錄
+type Store struct {
+	file    storage.File
+	Results []GameResult `json:"results"`
+}
+
+// Load - 從 store 讀取統計資料，資料不存在時回傳空的紀錄
+func Load(store storage.Store) (*Store, error) {
+	stats := &Store{}
+	file, err := storage.Open(store, FileName, stats)
+	stats.file = file
+	return stats, err
+}
+
+// Save - 寫回資料檔
+func (s *Store) Save() error {
+	return s.file.Save(s)
+}
+
+// Record - 加入一場結束的遊戲
+func (s *Store) Record(result GameResult) {
+	s.Results = append(s.Results, result)
+}
+
+// Listener - 產生掛在 gameInstance 上的事件監聽者，遊戲勝負揭曉時記錄結果，onRecord 可用來寫回資料檔
+//
+// judge 是 gameInstance 的失敗分析，與失敗回顧共用，避免同一局分析兩次
+func (s *Store) Listener(level game.Level, gameInstance *game.Game, judge *solver.LossJudge, onRecord func(GameResult)) game.EventListener {
+	return func(event game.Event) {
+		if event.Type != game.EventWin && event.Type != game.EventExplode {
+			return
+		}
+		result := GameResult{
+			Level:        level,
+			Won:          event.Type == game.EventWin,
+			Milliseconds: gameInstance.GetElapsedDuration().Milliseconds(),
+			Date:         time.Now(),
+			Seed:         gameInstance.Seed,
+			Hints:        gameInstance.Hints,
+		}
+		if verdict, ok := judge.Verdict(); ok && !result.Won {
+			result.Loss, result.Risk = verdict.Kind, verdict.Probability
+		}
+		s.Record(result)
+		if onRecord != nil {
+			onRecord(result)
+		}
+	}
+}
+
+// Summary - 計算該難度的統計，紀錄依日期排序後計算連勝
+func (s *Store) Summary(level game.Level) Summary {
+	var results []GameResult
+	for _, result := range s.Results {
+		if result.Level == level {
+			results = append(results, result)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return results[i].Date.Before(results[j].Date)
+	})
+
+	var summary Summary
+	var winTimes []time.Duration
+	var totalTime time.Duration
+	streak := 0
+	for _, result := range results {
+		summary.Played++
+		if result.Assisted() {
+			summary.Assisted++
+		}
+		switch result.Loss {
+		case solver.LossForced:
+			summary.Unlucky++
+		case solver.LossAvoidable, solver.LossCareless:
+			summary.Careless++
+		}
+		if !result.Won {
+			streak = 0
+			continue
+		}
+		summary.Won++
+		streak++
+		summary.LongestStreak = max(summary.LongestStreak, streak)
+		winTime := time.Duration(result.Milliseconds) * time.Millisecond
+		winTimes = append(winTimes, winTime)
+		totalTime += winTime
+	}
+	summary.CurrentStreak = streak
+	if summary.Played > 0 {
+		summary.WinRate = float64(summary.Won) / float64(summary.Played)
+	}
+	if len(winTimes) == 0 {
+		return summary
+	}
+	sort.Slice(winTimes, func(i, j int) bool { return winTimes[i] < winTimes[j] })
+	summary.AverageTime = totalTime / time.Duration(len(winTimes))
+	middle := len(winTimes) / 2
+	summary.MedianTime = winTimes[middle]
+	if len(winTimes)%2 == 0 {
+		summary.MedianTime = (winTimes[middle-1] + winTimes[middle]) / 2
+	}
+	summary.Histogram = histogram(winTimes)
+	return summary
+}
+
+// histogram - 將已排序的獲勝時間以整數秒寬度分成 HistogramBuckets 個區間
+func histogram(sortedTimes []time.Duration) []Bucket {
+	maxSeconds := int64(sortedTimes[len(sortedTimes)-1] / time.Second)
+	width := time.Duration(maxSeconds/HistogramBuckets+1) * time.Second
+	buckets := make([]Bucket, HistogramBuckets)
+	for i := range buckets {
+		buckets[i].From = time.Duration(i) * width
+		buckets[i].To = time.Duration(i+1) * width
+	}
+	for _, winTime := range sortedTimes {
+		buckets[min(int(winTime/width), HistogramBuckets-1)].Count++
+	}
+	return buckets
+}
+
+// ExportCSV - 以 CSV 匯出所有紀錄
+func (s *Store) ExportCSV(w io.Writer) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write([]string{"date", "level", "won", "milliseconds", "seed", "hints", "loss", "risk"}); err != nil {
+		return err
+	}
+	for _, result := range s.Results {
+		if err := writer.Write([]string{
+			result.Date.Format(time.RFC3339),
+			game.LevelMessage[result.Level],
+			strconv.FormatBool(result.Won),
+			strconv.FormatInt(result.Milliseconds, 10),
+			strconv.FormatInt(result.Seed, 10),
+			strconv.Itoa(result.Hints),
+			string(result.Loss),
+			strconv.FormatFloat(result.Risk, 'f', 3, 64),
+		}); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// ExportJSON - 以 JSON 匯出所有紀錄
+func (s *Store) ExportJSON(w io.Writer) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(s.Results)
+}
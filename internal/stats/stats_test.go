@@ -0,0 +1,127 @@
+package stats
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/game"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/solver"
+	"github.com/leetcode-golang-classroom/mine-sweeper/internal/storage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func result(day int, won bool, seconds int64) GameResult {
+	return GameResult{
+		Level:        game.Easy,
+		Won:          won,
+		Milliseconds: seconds * 1000,
+		Date:         time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestSummary(t *testing.T) {
+	store := &Store{}
+	// 故意打亂順序，Summary 需依日期計算連勝
+	for _, r := range []GameResult{
+		result(3, true, 20),
+		result(1, true, 10),
+		result(2, true, 30),
+		{Level: game.Easy, Won: false, Milliseconds: 5000, Date: time.Date(2026, 1, 4, 0, 0, 0, 0, time.UTC), Loss: solver.LossForced},
+		result(5, true, 40),
+		result(6, true, 16),
+		{Level: game.Easy, Won: false, Date: time.Date(2026, 1, 7, 0, 0, 0, 0, time.UTC), Hints: 2, Loss: solver.LossCareless},
+	} {
+		store.Record(r)
+	}
+	store.Record(GameResult{Level: game.Hard, Won: true, Date: time.Now()})
+
+	summary := store.Summary(game.Easy)
+	assert.Equal(t, 7, summary.Played)
+	assert.Equal(t, 5, summary.Won)
+	assert.Equal(t, 1, summary.Assisted)
+	assert.Equal(t, 1, summary.Unlucky)
+	assert.Equal(t, 1, summary.Careless)
+	assert.InDelta(t, 5.0/7, summary.WinRate, 1e-9)
+	assert.Equal(t, 0, summary.CurrentStreak)
+	assert.Equal(t, 3, summary.LongestStreak)
+	assert.Equal(t, 23200*time.Millisecond, summary.AverageTime)
+	assert.Equal(t, 20*time.Second, summary.MedianTime)
+
+	require.Len(t, summary.Histogram, HistogramBuckets)
+	assert.Equal(t, 6*time.Second, summary.Histogram[0].To)
+	total := 0
+	for _, bucket := range summary.Histogram {
+		total += bucket.Count
+	}
+	assert.Equal(t, 5, total)
+	assert.Equal(t, 1, summary.Histogram[HistogramBuckets-2].Count+summary.Histogram[HistogramBuckets-1].Count)
+
+	assert.Equal(t, Summary{}, store.Summary(game.Medium))
+}
+
+func TestListener(t *testing.T) {
+	store := &Store{}
+	gameInstance := game.NewGameWithSeed(2, 2, 0, 5)
+	var recorded []GameResult
+	gameInstance.AddListener(store.Listener(game.Medium, gameInstance, solver.NewLossJudge(gameInstance), func(result GameResult) {
+		recorded = append(recorded, result)
+	}))
+	gameInstance.ToggleFlag(0, 0)
+	gameInstance.UseHint(1, 1, 0)
+	gameInstance.RevealCell(1, 1)
+	require.Len(t, store.Results, 1)
+	assert.Equal(t, store.Results, recorded)
+	assert.True(t, store.Results[0].Won)
+	assert.Equal(t, game.Medium, store.Results[0].Level)
+	assert.Equal(t, int64(5), store.Results[0].Seed)
+	assert.True(t, store.Results[0].Assisted())
+}
+
+func TestListenerRecordsLoss(t *testing.T) {
+	store := &Store{}
+	gameInstance, err := game.LoadGame(strings.NewReader("*..\n...\n...\n"))
+	require.NoError(t, err)
+	judge := solver.NewLossJudge(gameInstance)
+	gameInstance.AddListener(store.Listener(game.Easy, gameInstance, judge, nil))
+	gameInstance.RevealCell(0, 0)
+	require.Len(t, store.Results, 1)
+	assert.False(t, store.Results[0].Won)
+	assert.Equal(t, solver.LossForced, store.Results[0].Loss)
+	assert.InDelta(t, 1.0/9, store.Results[0].Risk, 1e-9)
+	// 失敗回顧取得的是同一次分析
+	verdict, ok := judge.Verdict()
+	require.True(t, ok)
+	assert.Equal(t, store.Results[0].Risk, verdict.Probability)
+}
+
+func TestExportAndPersist(t *testing.T) {
+	dir := storage.Dir(t.TempDir())
+	store, err := Load(dir)
+	require.NoError(t, err)
+	store.Record(result(1, true, 10))
+	assisted := result(2, false, 3)
+	assisted.Hints = 1
+	assisted.Loss, assisted.Risk = solver.LossAvoidable, 0.25
+	store.Record(assisted)
+	require.NoError(t, store.Save())
+
+	loaded, err := Load(dir)
+	require.NoError(t, err)
+	assert.Equal(t, store.Results, loaded.Results)
+
+	var csvOutput bytes.Buffer
+	require.NoError(t, loaded.ExportCSV(&csvOutput))
+	lines := strings.Split(strings.TrimSpace(csvOutput.String()), "\n")
+	assert.Equal(t, []string{
+		"date,level,won,milliseconds,seed,hints,loss,risk",
+		"2026-01-01T00:00:00Z,Easy,true,10000,0,0,,0.000",
+		"2026-01-02T00:00:00Z,Easy,false,3000,0,1,avoidable,0.250",
+	}, lines)
+
+	var jsonOutput bytes.Buffer
+	require.NoError(t, loaded.ExportJSON(&jsonOutput))
+	assert.Contains(t, jsonOutput.String(), `"ms": 10000`)
+}
@@ -0,0 +1,38 @@
+//go:build !js
+
+package storage
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// Default - 桌面版使用使用者設定目錄下的資料目錄，取得失敗時回傳 Memory 與錯誤
+func Default() (Store, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return Memory{}, err
+	}
+	return Dir(filepath.Join(configDir, appDirName)), nil
+}
+
+// Export - 桌面版將匯出的檔案寫到資料目錄，回傳檔案所在的目錄
+func Export(name string, write func(io.Writer) error) (string, error) {
+	path, err := Path(name)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return "", err
+	}
+	return filepath.Dir(path), file.Close()
+}
@@ -0,0 +1,92 @@
+//go:build js
+
+package storage
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"syscall/js"
+)
+
+// LocalStorage - 網頁版將資料以 JSON 字串存在瀏覽器的 localStorage
+type LocalStorage struct {
+	storage js.Value
+}
+
+// key - localStorage 的 key，加上 appDirName 避免與同網域的其他頁面衝突
+func (LocalStorage) key(name string) string {
+	return appDirName + "/" + name
+}
+
+// Load - 讀取 localStorage 中的資料
+func (s LocalStorage) Load(name string, v any) error {
+	item := s.storage.Call("getItem", s.key(name))
+	if item.IsNull() {
+		return nil
+	}
+	return json.Unmarshal([]byte(item.String()), v)
+}
+
+// Save - 寫回 localStorage，超過瀏覽器的容量上限時回傳錯誤
+func (s LocalStorage) Save(name string, v any) (err error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("storage: %v", r)
+		}
+	}()
+	s.storage.Call("setItem", s.key(name), string(data))
+	return nil
+}
+
+// Default - 網頁版使用瀏覽器的 localStorage，瀏覽器停用時 (例如部分無痕模式) 回傳 Memory 與錯誤
+func Default() (store Store, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			store, err = Memory{}, fmt.Errorf("storage: %v", r)
+		}
+	}()
+	storage := js.Global().Get("localStorage")
+	if storage.IsUndefined() || storage.IsNull() {
+		return Memory{}, errors.New("storage: localStorage is unavailable")
+	}
+	return LocalStorage{storage: storage}, nil
+}
+
+// Export - 網頁版以瀏覽器下載匯出的檔案，回傳 "downloads"
+func Export(name string, write func(io.Writer) error) (location string, err error) {
+	var buf bytes.Buffer
+	if err := write(&buf); err != nil {
+		return "", err
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			location, err = "", fmt.Errorf("storage: %v", r)
+		}
+	}()
+	data := js.Global().Get("Uint8Array").New(buf.Len())
+	js.CopyBytesToJS(data, buf.Bytes())
+	url := js.Global().Get("URL").Call("createObjectURL", js.Global().Get("Blob").New([]any{data}))
+	document := js.Global().Get("document")
+	link := document.Call("createElement", "a")
+	link.Set("href", url)
+	link.Set("download", name)
+	document.Get("body").Call("appendChild", link)
+	link.Call("click")
+	link.Call("remove")
+	// 下載開始後才釋放 URL，立刻釋放會讓部分瀏覽器取消下載
+	var revoke js.Func
+	revoke = js.FuncOf(func(js.Value, []js.Value) any {
+		js.Global().Get("URL").Call("revokeObjectURL", url)
+		revoke.Release()
+		return nil
+	})
+	js.Global().Call("setTimeout", revoke, 1000)
+	return "downloads", nil
+}
@@ -0,0 +1,115 @@
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+)
+
+// appDirName - 使用者設定目錄下存放遊戲資料的目錄名稱
+const appDirName = "mine-sweeper"
+
+// Store - 以名稱讀寫 JSON 資料的位置
+type Store interface {
+	Load(name string, v any) error // 資料不存在時保持 v 不變並回傳 nil
+	Save(name string, v any) error
+}
+
+// Dir - 將資料存成目錄下的 JSON 檔
+type Dir string
+
+// Load - 讀取目錄下的資料檔
+func (d Dir) Load(name string, v any) error {
+	return LoadJSON(filepath.Join(string(d), name), v)
+}
+
+// Save - 寫回目錄下的資料檔
+func (d Dir) Save(name string, v any) error {
+	return SaveJSON(filepath.Join(string(d), name), v)
+}
+
+// Memory - 不保存任何資料，資料只存在記憶體中
+type Memory struct{}
+
+// Load - 沒有任何資料，保持 v 不變
+func (Memory) Load(string, any) error { return nil }
+
+// Save - 不保存
+func (Memory) Save(string, any) error { return nil }
+
+// LoadDefault - 以 load 從 Default 讀取資料，失敗時記錄錯誤並使用 load 回傳的只存在記憶體中的資料
+func LoadDefault[T any](label string, load func(Store) (T, error)) T {
+	store, err := Default()
+	if err != nil {
+		log.Printf("%s: %v", label, err)
+	}
+	data, err := load(store)
+	if err != nil {
+		log.Printf("%s: %v", label, err)
+	}
+	return data
+}
+
+// File - 保存在 Store 中的一份資料，零值只存在記憶體中
+type File struct {
+	store Store
+	name  string
+}
+
+// Open - 從 store 讀取 name 到 v 並回傳之後寫回用的 File
+//
+// 讀取失敗時回傳只存在記憶體中的 File，避免覆寫無法解析的資料
+func Open(store Store, name string, v any) (File, error) {
+	if err := store.Load(name, v); err != nil {
+		return File{}, err
+	}
+	return File{store: store, name: name}, nil
+}
+
+// Save - 寫回 v，只存在記憶體中時不做任何事
+func (f File) Save(v any) error {
+	if f.store == nil {
+		return nil
+	}
+	return f.store.Save(f.name, v)
+}
+
+// Path - 取得使用者設定目錄下的資料檔路徑
+func Path(name string) (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, appDirName, name), nil
+}
+
+// LoadJSON - 讀取 JSON 資料檔到 v，檔案不存在時保持 v 不變並回傳 nil
+func LoadJSON(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+// SaveJSON - 先寫入暫存檔再改名，避免寫到一半中斷造成資料檔損毀
+func SaveJSON(path string, v any) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o644); err != nil {
+		return err
+	}
+	return os.Rename(tmpPath, path)
+}
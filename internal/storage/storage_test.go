@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveAndLoadJSON(t *testing.T) {
+	type data struct {
+		Name  string
+		Value int
+	}
+	path := filepath.Join(t.TempDir(), "nested", "data.json")
+
+	// 檔案不存在時保持原值
+	loaded := data{Name: "default"}
+	require.NoError(t, LoadJSON(path, &loaded))
+	assert.Equal(t, data{Name: "default"}, loaded)
+
+	require.NoError(t, SaveJSON(path, data{Name: "saved", Value: 3}))
+	require.NoError(t, LoadJSON(path, &loaded))
+	assert.Equal(t, data{Name: "saved", Value: 3}, loaded)
+	_, err := os.Stat(path + ".tmp")
+	assert.True(t, os.IsNotExist(err))
+
+	require.NoError(t, os.WriteFile(path, []byte("{broken"), 0o644))
+	assert.Error(t, LoadJSON(path, &loaded))
+}
+
+func TestOpen(t *testing.T) {
+	type data struct {
+		Value int
+	}
+	dir := Dir(t.TempDir())
+
+	loaded := data{}
+	file, err := Open(dir, "data.json", &loaded)
+	require.NoError(t, err)
+	require.NoError(t, file.Save(data{Value: 7}))
+	_, err = Open(dir, "data.json", &loaded)
+	require.NoError(t, err)
+	assert.Equal(t, data{Value: 7}, loaded)
+
+	// 無法解析的資料檔不會被覆寫
+	path := filepath.Join(string(dir), "broken.json")
+	require.NoError(t, os.WriteFile(path, []byte("{broken"), 0o644))
+	file, err = Open(dir, "broken.json", &loaded)
+	assert.Error(t, err)
+	require.NoError(t, file.Save(data{Value: 1}))
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "{broken", string(content))
+
+	// Memory 不保存任何資料
+	file, err = Open(Memory{}, "data.json", &loaded)
+	require.NoError(t, err)
+	require.NoError(t, file.Save(data{Value: 2}))
+	assert.Equal(t, data{Value: 7}, loaded)
+}
+
+func TestExport(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+	t.Setenv("HOME", t.TempDir())
+	location, err := Export("export.csv", func(w io.Writer) error {
+		_, err := io.WriteString(w, "a,b\n")
+		return err
+	})
+	require.NoError(t, err)
+	content, err := os.ReadFile(filepath.Join(location, "export.csv"))
+	require.NoError(t, err)
+	assert.Equal(t, "a,b\n", string(content))
+}